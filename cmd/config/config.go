@@ -1,9 +1,11 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -12,56 +14,78 @@ import (
 var configCmd = &cobra.Command{
 	Use:   "config",
 	Short: "Modify and view the configuration",
+	// Config commands only read and write esctl.yml. Skipping the root
+	// initialization keeps them working without resolving secrets or
+	// connecting, e.g. to switch away from a context whose password helper fails.
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return nil
+	},
 }
 
 // Flags for the add-context command
 var (
-	contextName     string
-	contextHost     string
-	contextPort     int
-	contextProtocol string
-	contextUsername string
-	contextPassword string
+	contextName         string
+	contextHost         string
+	contextPort         int
+	contextProtocol     string
+	contextUsername     string
+	contextPassword     string
+	contextAPIKey       string
+	contextToken        string
+	contextCloudID      string
+	contextPasswordFrom string
+	contextAPIKeyFrom   string
+	contextTokenFrom    string
+	contextCACert       string
+	contextClientCert   string
+	contextClientKey    string
+	contextServerName   string
+	contextInsecure     bool
+	contextProxy        string
+	contextHeaders      map[string]string
+	contextTimeout      string
+	contextRetries      int
+	contextRetryBackoff string
 )
 
 var addContextCmd = &cobra.Command{
 	Use:   "add-context",
 	Short: "Add a new context to the configuration",
 	Long:  `Add a new named context with connection details (e.g., host, port, username, password) to esctl.yml`,
-	Run:   runAddContext,
+	RunE:  runAddContext,
 }
 
 var updateContextCmd = &cobra.Command{
 	Use:   "update-context",
 	Short: "Update an existing context",
 	Long:  `Update an existing context with new connection details (e.g., host, port, username, password) in esctl.yml`,
-	Run:   runUpdateContext,
+	RunE:  runUpdateContext,
 }
 
 var deleteContextCmd = &cobra.Command{
 	Use:   "delete-context",
 	Short: `Delete a context`,
 	Long:  `Delete an existing context from the configuration`,
-	Run:   runDeleteContext,
+	RunE:  runDeleteContext,
 }
 
 var useContextCmd = &cobra.Command{
 	Use:   "use-context",
 	Short: "Set the current context",
 	Long:  `Set the current context to connect to. This command updates the 'current-context' field in the configuration file.`,
-	Run:   runUseContext,
+	RunE:  runUseContext,
 }
 
 var getContextsCmd = &cobra.Command{
 	Use:   "get-contexts",
 	Short: "List the contexts defined in the esctl.yml file",
-	Run:   runGetContexts,
+	RunE:  runGetContexts,
 }
 
 var currentContextCmd = &cobra.Command{
 	Use:   "current-context",
 	Short: "Display the current context",
-	Run:   runCurrentContext,
+	RunE:  runCurrentContext,
 }
 
 func init() {
@@ -80,21 +104,53 @@ func init() {
 	// Define the flags for `add-context`
 	addContextCmd.Flags().StringVarP(&contextName, "name", "n", "", "Name of the new context (required)")
 	addContextCmd.Flags().StringVarP(&contextHost, "host", "", "", "Elasticsearch host, e.g. example.com")
+	addContextCmd.Flags().StringVar(&contextCloudID, "cloud-id", "", "Elastic Cloud ID, used instead of host, port and protocol")
 	addContextCmd.Flags().IntVar(&contextPort, "port", 9200, "Elasticsearch port (default: 9200)")
 	addContextCmd.Flags().StringVar(&contextProtocol, "protocol", "http", "Protocol, e.g. http or https")
 	addContextCmd.Flags().StringVarP(&contextUsername, "username", "u", "", "Username for Elasticsearch (if needed)")
 	addContextCmd.Flags().StringVarP(&contextPassword, "password", "p", "", "Password for Elasticsearch (if needed)")
+	addContextCmd.Flags().StringVar(&contextAPIKey, "api-key", "", "API key for Elasticsearch (base64 encoded id:api_key)")
+	addContextCmd.Flags().StringVar(&contextToken, "token", "", "Bearer token for Elasticsearch")
+	addContextCmd.Flags().StringVar(&contextPasswordFrom, "password-from", "", "Read the password from 'env:NAME', 'file:PATH' or 'command:PROGRAM [ARGS...]' instead of storing it")
+	addContextCmd.Flags().StringVar(&contextAPIKeyFrom, "api-key-from", "", "Read the API key from 'env:NAME', 'file:PATH' or 'command:PROGRAM [ARGS...]' instead of storing it")
+	addContextCmd.Flags().StringVar(&contextTokenFrom, "token-from", "", "Read the bearer token from 'env:NAME', 'file:PATH' or 'command:PROGRAM [ARGS...]' instead of storing it")
+	addContextCmd.Flags().StringVar(&contextCACert, "ca-cert", "", "Path to a PEM encoded CA bundle used to verify the cluster")
+	addContextCmd.Flags().StringVar(&contextClientCert, "client-cert", "", "Path to a PEM encoded client certificate for mutual TLS")
+	addContextCmd.Flags().StringVar(&contextClientKey, "client-key", "", "Path to the PEM encoded key of the client certificate")
+	addContextCmd.Flags().StringVar(&contextServerName, "server-name", "", "Server name used to verify the cluster certificate")
+	addContextCmd.Flags().BoolVar(&contextInsecure, "insecure-skip-verify", false, "Skip verification of the cluster certificate")
+	addContextCmd.Flags().StringVar(&contextProxy, "proxy", "", "HTTP or SOCKS5 proxy URL, e.g. 'socks5://localhost:1080'")
+	addContextCmd.Flags().StringToStringVar(&contextHeaders, "header", nil, "Extra HTTP header sent with every request as 'key=value', can be repeated")
+	addContextCmd.Flags().StringVar(&contextTimeout, "timeout", "", "Timeout of a single request, e.g. '30s'")
+	addContextCmd.Flags().IntVar(&contextRetries, "retries", 0, "Number of retries on connection errors and 429, 502, 503 or 504 responses")
+	addContextCmd.Flags().StringVar(&contextRetryBackoff, "retry-backoff", "", "Initial wait between retries, e.g. '500ms'")
 	// Mark name as required
 	_ = addContextCmd.MarkFlagRequired("name")
-	_ = addContextCmd.MarkFlagRequired("host")
+	addContextCmd.MarkFlagsOneRequired("host", "cloud-id")
 
 	// Define the flags for `update-context`
 	updateContextCmd.Flags().StringVarP(&contextName, "name", "n", "", "Name of the context to update (required)")
 	updateContextCmd.Flags().StringVarP(&contextHost, "host", "", "", "Elasticsearch host")
+	updateContextCmd.Flags().StringVar(&contextCloudID, "cloud-id", "", "Elastic Cloud ID, used instead of host, port and protocol")
 	updateContextCmd.Flags().IntVar(&contextPort, "port", 9200, "Elasticsearch port")
 	updateContextCmd.Flags().StringVar(&contextProtocol, "protocol", "http", "Protocol, e.g. http or https")
 	updateContextCmd.Flags().StringVarP(&contextUsername, "username", "u", "", "Username for Elasticsearch")
 	updateContextCmd.Flags().StringVarP(&contextPassword, "password", "p", "", "Password for Elasticsearch")
+	updateContextCmd.Flags().StringVar(&contextAPIKey, "api-key", "", "API key for Elasticsearch (base64 encoded id:api_key)")
+	updateContextCmd.Flags().StringVar(&contextToken, "token", "", "Bearer token for Elasticsearch")
+	updateContextCmd.Flags().StringVar(&contextPasswordFrom, "password-from", "", "Read the password from 'env:NAME', 'file:PATH' or 'command:PROGRAM [ARGS...]' instead of storing it")
+	updateContextCmd.Flags().StringVar(&contextAPIKeyFrom, "api-key-from", "", "Read the API key from 'env:NAME', 'file:PATH' or 'command:PROGRAM [ARGS...]' instead of storing it")
+	updateContextCmd.Flags().StringVar(&contextTokenFrom, "token-from", "", "Read the bearer token from 'env:NAME', 'file:PATH' or 'command:PROGRAM [ARGS...]' instead of storing it")
+	updateContextCmd.Flags().StringVar(&contextCACert, "ca-cert", "", "Path to a PEM encoded CA bundle used to verify the cluster")
+	updateContextCmd.Flags().StringVar(&contextClientCert, "client-cert", "", "Path to a PEM encoded client certificate for mutual TLS")
+	updateContextCmd.Flags().StringVar(&contextClientKey, "client-key", "", "Path to the PEM encoded key of the client certificate")
+	updateContextCmd.Flags().StringVar(&contextServerName, "server-name", "", "Server name used to verify the cluster certificate")
+	updateContextCmd.Flags().BoolVar(&contextInsecure, "insecure-skip-verify", false, "Skip verification of the cluster certificate")
+	updateContextCmd.Flags().StringVar(&contextProxy, "proxy", "", "HTTP or SOCKS5 proxy URL, e.g. 'socks5://localhost:1080'")
+	updateContextCmd.Flags().StringToStringVar(&contextHeaders, "header", nil, "Extra HTTP header sent with every request as 'key=value', can be repeated")
+	updateContextCmd.Flags().StringVar(&contextTimeout, "timeout", "", "Timeout of a single request, e.g. '30s'")
+	updateContextCmd.Flags().IntVar(&contextRetries, "retries", 0, "Number of retries on connection errors and 429, 502, 503 or 504 responses")
+	updateContextCmd.Flags().StringVar(&contextRetryBackoff, "retry-backoff", "", "Initial wait between retries, e.g. '500ms'")
 	// Mark name as required
 	_ = updateContextCmd.MarkFlagRequired("name")
 
@@ -109,14 +165,16 @@ func Cmd() *cobra.Command {
 }
 
 // runAddContext is executed when someone calls `esctl config add-context --host=... --name=...`
-func runAddContext(cmd *cobra.Command, args []string) {
+func runAddContext(cmd *cobra.Command, args []string) error {
 	// 1. Parse existing config
-	config := ParseConfigFile()
+	config, err := ParseConfigFile()
+	if err != nil {
+		return err
+	}
 
 	// 2. Validate required flags
 	if contextName == "" {
-		fmt.Println("Error: --name is required")
-		os.Exit(1)
+		return errors.New("--name is required")
 	}
 
 	contextExists := false
@@ -128,18 +186,46 @@ func runAddContext(cmd *cobra.Command, args []string) {
 	}
 
 	if contextExists {
-		fmt.Printf("Error: Context already exist with the name '%s' in the configuration.\n", contextName)
-		os.Exit(1)
+		return Errorf("context already exist with the name '%s' in the configuration", contextName)
 	}
 
 	// 3. Create a new Context from the flags
+	sources, err := parseSecretSourceFlags()
+	if err != nil {
+		return err
+	}
+
+	if err := validateRetryFlags(); err != nil {
+		return err
+	}
+
 	newCtx := Context{
-		Name:     contextName,
-		Host:     contextHost,
-		Port:     contextPort,
-		Protocol: contextProtocol,
-		Username: contextUsername,
-		Password: contextPassword,
+		Name:            contextName,
+		Host:            contextHost,
+		CloudID:         contextCloudID,
+		Port:            contextPort,
+		Protocol:        contextProtocol,
+		Username:        contextUsername,
+		Password:        contextPassword,
+		APIKey:          contextAPIKey,
+		BearerToken:     contextToken,
+		PasswordFrom:    sources.password,
+		APIKeyFrom:      sources.apiKey,
+		BearerTokenFrom: sources.token,
+		TLS: TLS{
+			CACert:             contextCACert,
+			ClientCert:         contextClientCert,
+			ClientKey:          contextClientKey,
+			ServerName:         contextServerName,
+			InsecureSkipVerify: contextInsecure,
+		},
+		Proxy:        contextProxy,
+		Headers:      contextHeaders,
+		Timeout:      contextTimeout,
+		RetryBackoff: contextRetryBackoff,
+	}
+	if cmd.Flags().Changed("retries") {
+		newCtx.Retries = &contextRetries
 	}
 
 	// 4. Append to existing contexts
@@ -149,22 +235,32 @@ func runAddContext(cmd *cobra.Command, args []string) {
 	viper.Set("contexts", config.Contexts)
 
 	// 6. Write the updated config to file
-	err := viper.WriteConfig()
-	if err != nil {
-		fmt.Printf("Error writing updated configuration: %s\n", err)
-		os.Exit(1)
+	if err := viper.WriteConfig(); err != nil {
+		return Errorf("failed to write updated configuration: %w", err)
 	}
 
 	// 7. Print success or new context
 	fmt.Printf("Context %q added successfully.\n", contextName)
+	return nil
 }
 
-func runUpdateContext(cmd *cobra.Command, args []string) {
-	config := ParseConfigFile()
+func runUpdateContext(cmd *cobra.Command, args []string) error {
+	config, err := ParseConfigFile()
+	if err != nil {
+		return err
+	}
 
 	if contextName == "" {
-		fmt.Println("Error: --name is required")
-		os.Exit(1)
+		return errors.New("--name is required")
+	}
+
+	sources, err := parseSecretSourceFlags()
+	if err != nil {
+		return err
+	}
+
+	if err := validateRetryFlags(); err != nil {
+		return err
 	}
 
 	contextExists := false
@@ -176,6 +272,9 @@ func runUpdateContext(cmd *cobra.Command, args []string) {
 			if contextHost != "" {
 				config.Contexts[i].Host = contextHost
 			}
+			if contextCloudID != "" {
+				config.Contexts[i].CloudID = contextCloudID
+			}
 			if contextPort != 0 {
 				config.Contexts[i].Port = contextPort
 			}
@@ -188,35 +287,83 @@ func runUpdateContext(cmd *cobra.Command, args []string) {
 			if contextPassword != "" {
 				config.Contexts[i].Password = contextPassword
 			}
+			if contextAPIKey != "" {
+				config.Contexts[i].APIKey = contextAPIKey
+			}
+			if contextToken != "" {
+				config.Contexts[i].BearerToken = contextToken
+			}
+			if sources.password != nil {
+				config.Contexts[i].PasswordFrom = sources.password
+			}
+			if sources.apiKey != nil {
+				config.Contexts[i].APIKeyFrom = sources.apiKey
+			}
+			if sources.token != nil {
+				config.Contexts[i].BearerTokenFrom = sources.token
+			}
+			if contextCACert != "" {
+				config.Contexts[i].TLS.CACert = contextCACert
+			}
+			if contextClientCert != "" {
+				config.Contexts[i].TLS.ClientCert = contextClientCert
+			}
+			if contextClientKey != "" {
+				config.Contexts[i].TLS.ClientKey = contextClientKey
+			}
+			if contextServerName != "" {
+				config.Contexts[i].TLS.ServerName = contextServerName
+			}
+			if cmd.Flags().Changed("insecure-skip-verify") {
+				config.Contexts[i].TLS.InsecureSkipVerify = contextInsecure
+			}
+			if contextProxy != "" {
+				config.Contexts[i].Proxy = contextProxy
+			}
+			if len(contextHeaders) > 0 && config.Contexts[i].Headers == nil {
+				config.Contexts[i].Headers = make(map[string]string, len(contextHeaders))
+			}
+			for key, value := range contextHeaders {
+				config.Contexts[i].Headers[key] = value
+			}
+			if contextTimeout != "" {
+				config.Contexts[i].Timeout = contextTimeout
+			}
+			if cmd.Flags().Changed("retries") {
+				config.Contexts[i].Retries = &contextRetries
+			}
+			if contextRetryBackoff != "" {
+				config.Contexts[i].RetryBackoff = contextRetryBackoff
+			}
 
 			break
 		}
 	}
 
 	if !contextExists {
-		fmt.Printf("Error: No context found with the name '%s' in the configuration.\n", contextName)
-		os.Exit(1)
+		return Errorf("no context found with the name '%s' in the configuration", contextName)
 	}
 
 	// Update Viper in-memory
 	viper.Set("contexts", config.Contexts)
 
 	// Write the updated config to file
-	err := viper.WriteConfig()
-	if err != nil {
-		fmt.Printf("Error writing updated configuration: %s\n", err)
-		os.Exit(1)
+	if err := viper.WriteConfig(); err != nil {
+		return Errorf("failed to write updated configuration: %w", err)
 	}
 
 	fmt.Printf("Context %q updated successfully.\n", contextName)
+	return nil
 }
 
-func runDeleteContext(cmd *cobra.Command, args []string) {
-	config := ParseConfigFile()
+func runDeleteContext(cmd *cobra.Command, args []string) error {
+	config, err := ParseConfigFile()
+	if err != nil {
+		return err
+	}
 
 	if contextName == "" {
-		fmt.Println("Error: --name is required")
-		os.Exit(1)
+		return errors.New("--name is required")
 	}
 
 	contextExists := false
@@ -229,27 +376,27 @@ func runDeleteContext(cmd *cobra.Command, args []string) {
 	}
 
 	if !contextExists {
-		fmt.Printf("Error: No context found with the name '%s' in the configuration.\n", contextName)
-		os.Exit(1)
+		return Errorf("no context found with the name '%s' in the configuration", contextName)
 	}
 
 	viper.Set("contexts", config.Contexts)
 
-	err := viper.WriteConfig()
-	if err != nil {
-		fmt.Printf("Error writing updated configuration: %s\n", err)
-		os.Exit(1)
+	if err := viper.WriteConfig(); err != nil {
+		return Errorf("failed to write updated configuration: %w", err)
 	}
 
 	fmt.Printf("Context %q deleted successfully.\n", contextName)
+	return nil
 }
 
-func runUseContext(cmd *cobra.Command, args []string) {
-	config := ParseConfigFile()
+func runUseContext(cmd *cobra.Command, args []string) error {
+	config, err := ParseConfigFile()
+	if err != nil {
+		return err
+	}
 
 	if contextName == "" {
-		fmt.Println("Error: --name is required")
-		os.Exit(1)
+		return errors.New("--name is required")
 	}
 
 	contextExists := false
@@ -261,28 +408,43 @@ func runUseContext(cmd *cobra.Command, args []string) {
 	}
 
 	if !contextExists {
-		fmt.Printf("Error: No context found with the name '%s' in the configuration.\n", contextName)
-		os.Exit(1)
+		return Errorf("no context found with the name '%s' in the configuration", contextName)
 	}
 
 	viper.Set("current-context", contextName)
 
-	err := viper.WriteConfig()
-	if err != nil {
-		fmt.Printf("Error writing updated configuration: %s\n", err)
-		os.Exit(1)
+	if err := viper.WriteConfig(); err != nil {
+		return Errorf("failed to write updated configuration: %w", err)
 	}
+	return nil
 }
 
-func runGetContexts(cmd *cobra.Command, args []string) {
-	config := ParseConfigFile()
+func runGetContexts(cmd *cobra.Command, args []string) error {
+	config, err := ParseConfigFile()
+	if err != nil {
+		return err
+	}
 	for _, context := range config.Contexts {
 		contextName := context.Name
 		if contextName == config.CurrentContext {
 			contextName += "(*)"
 		}
 		fmt.Printf("- name: %s\n", contextName)
-		fmt.Printf("  host: %s\n", context.Host)
+		if context.Host != "" {
+			fmt.Printf("  host: %s\n", context.Host)
+		}
+		if context.CloudID != "" {
+			fmt.Printf("  cloud-id: %s\n", context.CloudID)
+		}
+		if len(context.Hosts) > 0 {
+			fmt.Printf("  hosts:\n")
+			for _, host := range context.Hosts {
+				fmt.Printf("    - %s\n", host)
+			}
+		}
+		if context.HostSelection != "" {
+			fmt.Printf("  host-selection: %s\n", context.HostSelection)
+		}
 		if context.Protocol != "" {
 			fmt.Printf("  protocol: %s\n", context.Protocol)
 		}
@@ -293,23 +455,176 @@ func runGetContexts(cmd *cobra.Command, args []string) {
 			fmt.Printf("  username: %s\n", context.Username)
 		}
 		if context.Password != "" {
-			fmt.Printf("  password: %s\n", context.Password)
+			fmt.Printf("  password: %s\n", maskedSecret)
+		}
+		if context.PasswordFrom != nil {
+			fmt.Printf("  password-from: %s\n", context.PasswordFrom)
+		}
+		if context.Proxy != "" {
+			fmt.Printf("  proxy: %s\n", context.Proxy)
+		}
+		if len(context.Headers) > 0 {
+			fmt.Printf("  headers:\n")
+			for key, value := range context.Headers {
+				fmt.Printf("    %s: %s\n", key, value)
+			}
+		}
+		if context.Timeout != "" {
+			fmt.Printf("  timeout: %s\n", context.Timeout)
+		}
+		if context.Retries != nil {
+			fmt.Printf("  retries: %d\n", *context.Retries)
+		}
+		if context.RetryBackoff != "" {
+			fmt.Printf("  retry-backoff: %s\n", context.RetryBackoff)
+		}
+		if context.APIKey != "" {
+			fmt.Printf("  api-key: %s\n", maskedSecret)
+		}
+		if context.APIKeyFrom != nil {
+			fmt.Printf("  api-key-from: %s\n", context.APIKeyFrom)
+		}
+		if context.BearerToken != "" {
+			fmt.Printf("  bearer-token: %s\n", maskedSecret)
+		}
+		if context.BearerTokenFrom != nil {
+			fmt.Printf("  bearer-token-from: %s\n", context.BearerTokenFrom)
+		}
+		if context.TLS.CACert != "" {
+			fmt.Printf("  ca-cert: %s\n", context.TLS.CACert)
+		}
+		if context.TLS.ClientCert != "" {
+			fmt.Printf("  client-cert: %s\n", context.TLS.ClientCert)
+		}
+		if context.TLS.ClientKey != "" {
+			fmt.Printf("  client-key: %s\n", context.TLS.ClientKey)
+		}
+		if context.TLS.ServerName != "" {
+			fmt.Printf("  server-name: %s\n", context.TLS.ServerName)
+		}
+		if context.TLS.InsecureSkipVerify {
+			fmt.Printf("  insecure-skip-verify: %t\n", context.TLS.InsecureSkipVerify)
+		}
+	}
+	return nil
+}
+
+const maskedSecret = "********"
+
+type secretSources struct {
+	password *SecretSource
+	apiKey   *SecretSource
+	token    *SecretSource
+}
+
+func parseSecretSourceFlags() (secretSources, error) {
+	var sources secretSources
+	var err error
+
+	if contextPasswordFrom != "" {
+		if sources.password, err = ParseSecretSource(contextPasswordFrom); err != nil {
+			return sources, err
+		}
+	}
+	if contextAPIKeyFrom != "" {
+		if sources.apiKey, err = ParseSecretSource(contextAPIKeyFrom); err != nil {
+			return sources, err
+		}
+	}
+	if contextTokenFrom != "" {
+		if sources.token, err = ParseSecretSource(contextTokenFrom); err != nil {
+			return sources, err
+		}
+	}
+
+	return sources, nil
+}
+
+// validateRetryFlags rejects timeouts and retry settings that could not be
+// used when connecting with the context.
+func validateRetryFlags() error {
+	if contextTimeout != "" {
+		if timeout, err := time.ParseDuration(contextTimeout); err != nil || timeout < 0 {
+			return fmt.Errorf("invalid timeout %q, expected a duration like '30s'", contextTimeout)
+		}
+	}
+	if contextRetries < 0 {
+		return fmt.Errorf("invalid retries %d, expected 0 or more", contextRetries)
+	}
+	if contextRetryBackoff != "" {
+		if backoff, err := time.ParseDuration(contextRetryBackoff); err != nil || backoff <= 0 {
+			return fmt.Errorf("invalid retry-backoff %q, expected a duration like '500ms'", contextRetryBackoff)
 		}
 	}
+	return nil
 }
 
-func runCurrentContext(cmd *cobra.Command, args []string) {
-	config := ParseConfigFile()
+// ResolveSecrets replaces the secret references of a context with the values
+// they point to. Inline secrets are kept when no reference is set.
+func (c *Context) ResolveSecrets() error {
+	resolve := func(source *SecretSource, target *string, what string) error {
+		if source == nil {
+			return nil
+		}
+		value, err := source.Resolve()
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s of context '%s': %w", what, c.Name, err)
+		}
+		*target = value
+		return nil
+	}
+
+	if err := resolve(c.PasswordFrom, &c.Password, "password"); err != nil {
+		return err
+	}
+	if err := resolve(c.APIKeyFrom, &c.APIKey, "api key"); err != nil {
+		return err
+	}
+	return resolve(c.BearerTokenFrom, &c.BearerToken, "bearer token")
+}
+
+func runCurrentContext(cmd *cobra.Command, args []string) error {
+	config, err := ParseConfigFile()
+	if err != nil {
+		return err
+	}
 	fmt.Println(config.CurrentContext)
+	return nil
 }
 
 type Context struct {
-	Name     string `mapstructure:"name"`
-	Protocol string `mapstructure:"protocol"`
-	Host     string `mapstructure:"host"`
-	Port     int    `mapstructure:"port"`
-	Username string `mapstructure:"username"`
-	Password string `mapstructure:"password"`
+	Name            string            `mapstructure:"name" yaml:"name"`
+	Protocol        string            `mapstructure:"protocol" yaml:"protocol"`
+	Host            string            `mapstructure:"host" yaml:"host"`
+	Hosts           []string          `mapstructure:"hosts" yaml:"hosts,omitempty"`
+	HostSelection   string            `mapstructure:"host-selection" yaml:"host-selection,omitempty"`
+	CloudID         string            `mapstructure:"cloud-id" yaml:"cloud-id,omitempty"`
+	Proxy           string            `mapstructure:"proxy" yaml:"proxy,omitempty"`
+	Headers         map[string]string `mapstructure:"headers" yaml:"headers,omitempty"`
+	Timeout         string            `mapstructure:"timeout" yaml:"timeout,omitempty"`
+	Retries         *int              `mapstructure:"retries" yaml:"retries,omitempty"`
+	RetryBackoff    string            `mapstructure:"retry-backoff" yaml:"retry-backoff,omitempty"`
+	Port            int               `mapstructure:"port" yaml:"port"`
+	Username        string            `mapstructure:"username" yaml:"username"`
+	Password        string            `mapstructure:"password" yaml:"password"`
+	APIKey          string            `mapstructure:"api-key" yaml:"api-key,omitempty"`
+	BearerToken     string            `mapstructure:"bearer-token" yaml:"bearer-token,omitempty"`
+	PasswordFrom    *SecretSource     `mapstructure:"password-from" yaml:"password-from,omitempty"`
+	APIKeyFrom      *SecretSource     `mapstructure:"api-key-from" yaml:"api-key-from,omitempty"`
+	BearerTokenFrom *SecretSource     `mapstructure:"bearer-token-from" yaml:"bearer-token-from,omitempty"`
+	TLS             TLS               `mapstructure:",squash" yaml:",inline"`
+	// ReadOnly refuses requests that could change the cluster unless --allow-writes is passed
+	ReadOnly bool `mapstructure:"read-only" yaml:"read-only,omitempty"`
+}
+
+// TLS holds the per-context TLS settings. The fields are squashed into the
+// context so they appear next to host and port in esctl.yml.
+type TLS struct {
+	CACert             string `mapstructure:"ca-cert" yaml:"ca-cert,omitempty"`
+	ClientCert         string `mapstructure:"client-cert" yaml:"client-cert,omitempty"`
+	ClientKey          string `mapstructure:"client-key" yaml:"client-key,omitempty"`
+	ServerName         string `mapstructure:"server-name" yaml:"server-name,omitempty"`
+	InsecureSkipVerify bool   `mapstructure:"insecure-skip-verify" yaml:"insecure-skip-verify,omitempty"`
 }
 
 type Entity struct {
@@ -322,29 +637,43 @@ type Config struct {
 	Entities       map[string]Entity `mapstructure:"entities"`
 }
 
-func ParseConfigFile() *Config {
+// ParseConfigFile reads ~/.config/esctl.yml. Failures are returned as *Error.
+func ParseConfigFile() (*Config, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
-		fmt.Printf("Error getting user's home directory: %v\n", err)
-		os.Exit(1)
+		return nil, Errorf("failed to get user's home directory: %w", err)
 	}
 
 	viper.AddConfigPath(filepath.Join(home, ".config"))
 	viper.SetConfigName("esctl")
 	viper.SetConfigType("yml")
 
-	err = viper.ReadInConfig()
-	if err != nil {
-		fmt.Printf("Error reading config file: %s\n", err)
-		os.Exit(1)
+	if err := viper.ReadInConfig(); err != nil {
+		return nil, Errorf("failed to read config file: %w", err)
 	}
 
 	var config Config
-	err = viper.Unmarshal(&config)
-	if err != nil {
-		fmt.Printf("Error unmarshaling config into struct: %v\n", err)
-		os.Exit(1)
+	if err := viper.Unmarshal(&config); err != nil {
+		return nil, Errorf("failed to unmarshal config into struct: %w", err)
 	}
 
-	return &config
+	return &config, nil
+}
+
+// Error reports a missing or invalid configuration file or context.
+type Error struct {
+	Err error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Errorf formats an error like fmt.Errorf and wraps it in an *Error.
+func Errorf(format string, a ...interface{}) error {
+	return &Error{Err: fmt.Errorf(format, a...)}
 }
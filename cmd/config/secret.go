@@ -0,0 +1,100 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// SecretSource points to where a secret is read from, so it does not have to
+// be stored in esctl.yml. Exactly one of the fields is expected to be set.
+type SecretSource struct {
+	Env     string   `mapstructure:"env" yaml:"env,omitempty"`
+	File    string   `mapstructure:"file" yaml:"file,omitempty"`
+	Command []string `mapstructure:"command" yaml:"command,omitempty"`
+}
+
+// ParseSecretSource parses the flag form of a secret source:
+// 'env:NAME', 'file:PATH' or 'command:PROGRAM [ARGS...]'.
+func ParseSecretSource(spec string) (*SecretSource, error) {
+	kind, value, ok := strings.Cut(spec, ":")
+	if !ok || strings.TrimSpace(value) == "" {
+		return nil, fmt.Errorf("invalid secret source %q, expected env:NAME, file:PATH or command:PROGRAM", spec)
+	}
+
+	switch kind {
+	case "env":
+		return &SecretSource{Env: value}, nil
+	case "file":
+		return &SecretSource{File: value}, nil
+	case "command":
+		return &SecretSource{Command: strings.Fields(value)}, nil
+	default:
+		return nil, fmt.Errorf("unknown secret source type %q, expected env, file or command", kind)
+	}
+}
+
+// Resolve reads the secret from its source. Trailing newlines are stripped
+// so files and command output can be used as they are.
+func (s *SecretSource) Resolve() (string, error) {
+	switch {
+	case s.Env != "":
+		value, ok := os.LookupEnv(s.Env)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", s.Env)
+		}
+		return value, nil
+	case s.File != "":
+		path, err := expandHome(s.File)
+		if err != nil {
+			return "", err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret file: %w", err)
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	case len(s.Command) > 0:
+		var stdout bytes.Buffer
+		command := exec.Command(s.Command[0], s.Command[1:]...)
+		command.Stdin = os.Stdin
+		command.Stdout = &stdout
+		command.Stderr = os.Stderr
+		if err := command.Run(); err != nil {
+			return "", fmt.Errorf("credential command %q failed: %w", s.Command[0], err)
+		}
+		return strings.TrimRight(stdout.String(), "\r\n"), nil
+	default:
+		return "", fmt.Errorf("secret source has no env, file or command")
+	}
+}
+
+// String describes the source without revealing the secret.
+func (s *SecretSource) String() string {
+	switch {
+	case s.Env != "":
+		return "env:" + s.Env
+	case s.File != "":
+		return "file:" + s.File
+	case len(s.Command) > 0:
+		return "command:" + strings.Join(s.Command, " ")
+	default:
+		return ""
+	}
+}
+
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseSecretSource(t *testing.T) {
+	tests := []struct {
+		input       string
+		expected    string
+		expectError bool
+	}{
+		{"env:ES_PASSWORD", "env:ES_PASSWORD", false},
+		{"file:/etc/esctl/password", "file:/etc/esctl/password", false},
+		{"command:pass show es/prod", "command:pass show es/prod", false},
+		{"vault:secret/es", "", true},
+		{"env:", "", true},
+		{"ES_PASSWORD", "", true},
+	}
+
+	for _, test := range tests {
+		source, err := ParseSecretSource(test.input)
+
+		if test.expectError {
+			if err == nil {
+				t.Errorf("Expected error for input %s, but got nil", test.input)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Unexpected error for input %s: %v", test.input, err)
+			continue
+		}
+
+		if source.String() != test.expected {
+			t.Errorf("For input %s, expected %s, but got %s", test.input, test.expected, source.String())
+		}
+	}
+}
+
+func TestSecretSourceResolve(t *testing.T) {
+	t.Setenv("ESCTL_TEST_SECRET", "from-env")
+
+	path := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(path, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		source      SecretSource
+		expected    string
+		expectError bool
+	}{
+		{"Env", SecretSource{Env: "ESCTL_TEST_SECRET"}, "from-env", false},
+		{"Unset env", SecretSource{Env: "ESCTL_TEST_SECRET_UNSET"}, "", true},
+		{"File", SecretSource{File: path}, "from-file", false},
+		{"Command", SecretSource{Command: []string{"echo", "from-command"}}, "from-command", false},
+		{"Empty", SecretSource{}, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, err := test.source.Resolve()
+			if test.expectError {
+				if err == nil {
+					t.Errorf("Expected error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if value != test.expected {
+				t.Errorf("Resolve() = %q, want %q", value, test.expected)
+			}
+		})
+	}
+}
@@ -1,8 +1,8 @@
 package count
 
 import (
+	"context"
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
 
@@ -15,8 +15,8 @@ var countCmd = &cobra.Command{
 	Use:   "count [--index index] [--group-by field]",
 	Short: "Count documents in an index or in all indices matching a pattern",
 	Args:  cobra.NoArgs,
-	Run: func(cmd *cobra.Command, args []string) {
-		handleCount()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return handleCount(cmd.Context())
 	},
 }
 
@@ -24,14 +24,13 @@ func Cmd() *cobra.Command {
 	return countCmd
 }
 
-func handleCount() {
+func handleCount(ctx context.Context) error {
 	var counts map[string]es.GroupCount
 	var err error
 
-	counts, err = es.CountDocuments(flagIndex, flagTerm, flagExists, flagNested, flagGroupBy, flagSize, flagTimeout, flagRefresh)
+	counts, err = es.CountDocuments(ctx, flagIndex, flagTerm, flagExists, flagNested, flagGroupBy, flagSize, flagTimeout, flagRefresh)
 	if err != nil {
-		fmt.Printf("Failed to get document counts: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("Failed to get document counts: %w", err)
 	}
 
 	columnDefs := []output.ColumnDefaults{
@@ -40,7 +39,7 @@ func handleCount() {
 		{Header: "COUNT", Type: output.Number},
 	}
 
-	data := [][]string{}
+	table := output.NewTable(columnDefs, "INDEX", strings.ToUpper(flagGroupBy))
 
 	for index, groupCount := range counts {
 		for group, count := range groupCount {
@@ -50,21 +49,15 @@ func handleCount() {
 				"COUNT":                      strconv.Itoa(count),
 			}
 
-			row := make([]string, len(columnDefs))
-			for i, colDef := range columnDefs {
-				row[i] = rowData[colDef.Header]
-			}
-			data = append(data, row)
+			table.AddRow(map[string]interface{}{"index": index, "group": group, "count": count}, rowData)
 		}
 	}
 
-	if len(flagSortBy) > 0 {
-		sortCols := output.ParseSortColumns(flagSortBy)
-		output.PrintTable(columnDefs, data, sortCols)
-	} else {
-		sortCols := output.ParseSortColumns("INDEX")
-		output.PrintTable(columnDefs, data, sortCols)
+	sortBy := flagSortBy
+	if sortBy == "" {
+		sortBy = "INDEX"
 	}
+	return table.Print(output.ParseSortColumns(sortBy))
 }
 
 func init() {
@@ -0,0 +1,34 @@
+package create
+
+import (
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+var createCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create Elasticsearch entities",
+	Long: utils.Trim(`
+The 'create' command allows you to create Elasticsearch entities.
+
+Available Entities:
+  - index: Create an index.
+  - snapshot: Create a snapshot in a repository.`),
+	Example: utils.TrimAndIndent(`
+# Create an index with 3 primary shards.
+esctl create index my_index --shards 3
+
+# Snapshot all indices in a repository.
+esctl create snapshot nightly-2024.05.01 --repo my_repo`),
+}
+
+func init() {
+	createCmd.PersistentFlags().BoolVarP(&flagYes, "yes", "y", false, "Skip the confirmation prompt")
+
+	createCmd.AddCommand(createIndexCmd)
+	createCmd.AddCommand(createSnapshotCmd)
+}
+
+func Cmd() *cobra.Command {
+	return createCmd
+}
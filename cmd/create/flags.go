@@ -0,0 +1,13 @@
+package create
+
+var (
+	flagYes                bool
+	flagShards             int
+	flagReplicas           int
+	flagBody               string
+	flagRepository         string
+	flagIndices            string
+	flagIncludeGlobalState bool
+	flagPartial            bool
+	flagWait               bool
+)
@@ -0,0 +1,101 @@
+package create
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/pincher95/esctl/es"
+	"github.com/pincher95/esctl/output"
+	"github.com/spf13/cobra"
+)
+
+var createIndexCmd = &cobra.Command{
+	Use:   "index NAME",
+	Short: "Create an index",
+	Long: utils.Trim(`
+Create an index. Settings, mappings and aliases can be read from a JSON file with the same body as the create index API,
+'--shards' and '--replicas' take precedence over the settings in the file.`),
+	Example: utils.TrimAndIndent(`
+	# Create an index with 3 primary shards and 1 replica.
+	esctl create index my_index --shards 3 --replicas 1
+
+	# Create an index with the settings and mappings of a file, without asking for confirmation.
+	esctl create index my_index --body index.json --yes
+	`),
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return handleCreateIndex(cmd.Context(), cmd, args[0])
+	},
+}
+
+func init() {
+	createIndexCmd.Flags().IntVar(&flagShards, "shards", 0, "Number of primary shards")
+	createIndexCmd.Flags().IntVar(&flagReplicas, "replicas", 0, "Number of replicas of every primary shard")
+	createIndexCmd.Flags().StringVar(&flagBody, "body", "", "Path to a JSON file with the settings, mappings and aliases of the index")
+}
+
+func handleCreateIndex(ctx context.Context, cmd *cobra.Command, name string) error {
+	body := make(map[string]interface{})
+	if flagBody != "" {
+		data, err := os.ReadFile(flagBody)
+		if err != nil {
+			return fmt.Errorf("Failed to read index body: %w", err)
+		}
+		if err := json.Unmarshal(data, &body); err != nil {
+			return fmt.Errorf("Failed to parse index body %s: %w", flagBody, err)
+		}
+	}
+
+	if cmd.Flags().Changed("shards") {
+		setIndexSetting(body, "number_of_shards", flagShards)
+	}
+	if cmd.Flags().Changed("replicas") {
+		setIndexSetting(body, "number_of_replicas", flagReplicas)
+	}
+
+	if len(body) == 0 {
+		fmt.Fprintf(os.Stderr, "The index %s will be created with default settings.\n", name)
+	} else {
+		data, err := json.MarshalIndent(body, "  ", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to generate pretty JSON: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "The index %s will be created with:\n  %s\n", name, data)
+	}
+	if err := utils.Confirm("Create index "+name+"?", flagYes); err != nil {
+		return err
+	}
+
+	response, err := es.CreateIndex(ctx, name, body)
+	if err != nil {
+		return fmt.Errorf("Failed to create index %s: %w", name, err)
+	}
+
+	if output.Format() != output.FormatTable && !output.IsWide() {
+		return output.PrintObject(response)
+	}
+	fmt.Printf("index/%s created\n", name)
+	return nil
+}
+
+// setIndexSetting sets settings.index.<key> of a create index body, replacing
+// the same setting given in flat form, e.g. 'index.number_of_shards'.
+func setIndexSetting(body map[string]interface{}, key string, value interface{}) {
+	settings, ok := body["settings"].(map[string]interface{})
+	if !ok {
+		settings = make(map[string]interface{})
+		body["settings"] = settings
+	}
+	delete(settings, key)
+	delete(settings, "index."+key)
+
+	index, ok := settings["index"].(map[string]interface{})
+	if !ok {
+		index = make(map[string]interface{})
+		settings["index"] = index
+	}
+	index[key] = value
+}
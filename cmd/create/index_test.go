@@ -0,0 +1,37 @@
+package create
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSetIndexSetting(t *testing.T) {
+	body := map[string]interface{}{
+		"settings": map[string]interface{}{
+			"index.number_of_shards": 5,
+			"refresh_interval":       "30s",
+		},
+	}
+
+	setIndexSetting(body, "number_of_shards", 3)
+	setIndexSetting(body, "number_of_replicas", 0)
+
+	want := map[string]interface{}{
+		"settings": map[string]interface{}{
+			"refresh_interval": "30s",
+			"index": map[string]interface{}{
+				"number_of_shards":   3,
+				"number_of_replicas": 0,
+			},
+		},
+	}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("got %v, want %v", body, want)
+	}
+
+	empty := map[string]interface{}{}
+	setIndexSetting(empty, "number_of_shards", 1)
+	if got := empty["settings"].(map[string]interface{})["index"].(map[string]interface{})["number_of_shards"]; got != 1 {
+		t.Errorf("got %v shards in a new body, want 1", got)
+	}
+}
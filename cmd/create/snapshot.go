@@ -0,0 +1,76 @@
+package create
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/pincher95/esctl/es"
+	"github.com/pincher95/esctl/output"
+	"github.com/spf13/cobra"
+)
+
+var createSnapshotCmd = &cobra.Command{
+	Use:   "snapshot NAME",
+	Short: "Create a snapshot",
+	Long: utils.Trim(`
+Create a snapshot of all or some indices in a repository. The snapshot runs in the background unless '--wait' is given,
+use 'esctl get snapshot-progress --watch' to follow it.`),
+	Example: utils.TrimAndIndent(`
+	# Snapshot all indices and the cluster state.
+	esctl create snapshot nightly-2024.05.01 --repo my_repo
+
+	# Snapshot the logs indices only and wait until the snapshot is done.
+	esctl create snapshot logs-2024.05.01 --repo my_repo --indices 'logs-*' --include-global-state=false --wait
+	`),
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return handleCreateSnapshot(cmd.Context(), args[0])
+	},
+}
+
+func init() {
+	createSnapshotCmd.Flags().StringVarP(&flagRepository, "repo", "r", "", "Name of the snapshot repository")
+	createSnapshotCmd.Flags().StringVar(&flagIndices, "indices", "", "Comma-separated indices or patterns to snapshot, all indices by default")
+	createSnapshotCmd.Flags().BoolVar(&flagIncludeGlobalState, "include-global-state", true, "Include the cluster state, e.g. templates and persistent settings")
+	createSnapshotCmd.Flags().BoolVar(&flagPartial, "partial", false, "Allow a partial snapshot when some primary shards are unavailable")
+	createSnapshotCmd.Flags().BoolVar(&flagWait, "wait", false, "Wait for the snapshot to finish")
+	_ = createSnapshotCmd.MarkFlagRequired("repo")
+}
+
+func handleCreateSnapshot(ctx context.Context, name string) error {
+	request := es.CreateSnapshotRequest{
+		Indices:            flagIndices,
+		IncludeGlobalState: flagIncludeGlobalState,
+		Partial:            flagPartial,
+	}
+
+	indices := flagIndices
+	if indices == "" {
+		indices = "all indices"
+	}
+	fmt.Fprintf(os.Stderr, "The snapshot %s will be created in repository %s with %s", name, flagRepository, indices)
+	if request.IncludeGlobalState {
+		fmt.Fprint(os.Stderr, " and the cluster state")
+	}
+	fmt.Fprintln(os.Stderr, ".")
+	if err := utils.Confirm("Create snapshot "+name+"?", flagYes); err != nil {
+		return err
+	}
+
+	response, err := es.CreateSnapshot(ctx, flagRepository, name, request, flagWait)
+	if err != nil {
+		return fmt.Errorf("Failed to create snapshot %s: %w", name, err)
+	}
+
+	if output.Format() != output.FormatTable && !output.IsWide() {
+		return output.PrintObject(response)
+	}
+	if response.Snapshot == nil {
+		fmt.Printf("snapshot/%s started\n", name)
+		return nil
+	}
+	fmt.Printf("snapshot/%s created, %s, %d of %d shards successful\n", name, response.Snapshot.State, response.Snapshot.Shards.Successful, response.Snapshot.Shards.Total)
+	return nil
+}
@@ -0,0 +1,29 @@
+package delete
+
+import (
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+var deleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete Elasticsearch entities",
+	Long: utils.Trim(`
+The 'delete' command allows you to delete Elasticsearch entities.
+
+Available Entities:
+  - index: Delete indices matching a name or pattern.`),
+	Example: utils.TrimAndIndent(`
+# Delete all indices matching a pattern, after confirming the preview.
+esctl delete index 'logs-2023.*'`),
+}
+
+func init() {
+	deleteCmd.PersistentFlags().BoolVarP(&flagYes, "yes", "y", false, "Skip the confirmation prompt")
+
+	deleteCmd.AddCommand(deleteIndexCmd)
+}
+
+func Cmd() *cobra.Command {
+	return deleteCmd
+}
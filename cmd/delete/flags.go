@@ -0,0 +1,6 @@
+package delete
+
+var (
+	flagYes bool
+	flagAll bool
+)
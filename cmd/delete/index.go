@@ -0,0 +1,64 @@
+package delete
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/pincher95/esctl/es"
+	"github.com/pincher95/esctl/output"
+	"github.com/spf13/cobra"
+)
+
+var deleteIndexCmd = &cobra.Command{
+	Use:   "index NAME",
+	Short: "Delete indices",
+	Long: utils.Trim(`
+Delete the indices matching NAME, which may be a pattern, e.g. 'logs-2023.*'.
+The matching indices are listed and deleted by name only after confirmation, deleted data cannot be recovered.
+A pattern matching every index, e.g. '*' or '_all', also needs '--all'.`),
+	Example: utils.TrimAndIndent(`
+	# Delete an index.
+	esctl delete index my_index
+
+	# Delete all indices matching a pattern without asking for confirmation.
+	esctl delete index 'logs-2023.*' --yes
+	`),
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return handleDeleteIndex(cmd.Context(), args[0])
+	},
+}
+
+func init() {
+	deleteIndexCmd.Flags().BoolVar(&flagAll, "all", false, "Allow a pattern matching every index")
+}
+
+func handleDeleteIndex(ctx context.Context, pattern string) error {
+	indices, err := es.ResolveIndices(ctx, pattern, flagAll)
+	if err != nil {
+		return fmt.Errorf("Failed to retrieve indices: %w", err)
+	}
+
+	count := utils.Plural(len(indices), "index", "indices")
+	if err := utils.PrintIndexPreview("The following indices will be deleted:", indices); err != nil {
+		return err
+	}
+	if err := utils.Confirm("Delete "+count+"?", flagYes); err != nil {
+		return err
+	}
+
+	names := utils.IndexNames(indices)
+	response, err := es.DeleteIndices(ctx, names)
+	if err != nil {
+		return fmt.Errorf("Failed to delete %s: %w", count, err)
+	}
+
+	if output.Format() != output.FormatTable && !output.IsWide() {
+		return output.PrintObject(response)
+	}
+	for _, name := range names {
+		fmt.Printf("index/%s deleted\n", name)
+	}
+	return nil
+}
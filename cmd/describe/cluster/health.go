@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pincher95/esctl/cmd/utils"
@@ -26,8 +27,8 @@ var clusterHealthCmd = &cobra.Command{
 	# Retrieve detailed information about the cluster health including default settings.
 	esctl describe cluster health --index=my_index
 	`),
-	Run: func(cmd *cobra.Command, args []string) {
-		handleDescribeClusterHealth()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return handleDescribeClusterHealth(cmd.Context())
 	},
 }
 
@@ -37,12 +38,11 @@ func init() {
 	clusterHealthCmd.Flags().StringVar(&flagExpandWildcards, "expand-wildcards", "", "Expands wildcard expressions to concrete indexes. Combine multiple values with commas. Supported values are all, open, closed, hidden, and none. (Default is open)")
 }
 
-func handleDescribeClusterHealth() {
-	health, err := cluster.ClusterHealth(nil, &flagLevel, &flagExpandWildcards, &flagIndex)
+func handleDescribeClusterHealth(ctx context.Context) error {
+	health, err := cluster.ClusterHealth(ctx, nil, &flagLevel, &flagExpandWildcards, &flagIndex)
 	if err != nil {
-		fmt.Println("Failed to retrieve cluster information:", err)
-		return
+		return fmt.Errorf("Failed to retrieve cluster information: %w", err)
 	}
 
-	output.PrintJson(health)
+	return output.PrintObject(health)
 }
@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pincher95/esctl/cmd/utils"
@@ -22,8 +23,8 @@ var clusterSettingsCmd = &cobra.Command{
 
 	# Retrieve detailed information about the cluster settings including default settings.
 	esctl describe cluster settings --include-defaults`),
-	Run: func(cmd *cobra.Command, args []string) {
-		handleDescribeClusterSettings()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return handleDescribeClusterSettings(cmd.Context())
 	},
 }
 
@@ -32,12 +33,11 @@ func init() {
 	clusterSettingsCmd.Flags().BoolVar(&flagIncludeDefaults, "include-defaults", false, "If set, include default settings (Default is false)")
 }
 
-func handleDescribeClusterSettings() {
-	settings, err := cluster.ClusterSettings(nil, flagFlatSettings, flagIncludeDefaults)
+func handleDescribeClusterSettings(ctx context.Context) error {
+	settings, err := cluster.ClusterSettings(ctx, nil, flagFlatSettings, flagIncludeDefaults)
 	if err != nil {
-		fmt.Println("Failed to retrieve cluster information:", err)
-		return
+		return fmt.Errorf("Failed to retrieve cluster information: %w", err)
 	}
 
-	output.PrintJson(settings)
+	return output.PrintObject(settings)
 }
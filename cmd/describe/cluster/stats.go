@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pincher95/esctl/cmd/utils"
@@ -14,8 +15,8 @@ var clusterStatsCmd = &cobra.Command{
 	Short:   "Print detailed information about an entity",
 	Long:    "By default, it returns only settings that have been explicitly defined.",
 	Example: utils.TrimAndIndent(``),
-	Run: func(cmd *cobra.Command, args []string) {
-		handleDescribeClusterStats()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return handleDescribeClusterStats(cmd.Context())
 	},
 }
 
@@ -24,12 +25,11 @@ func init() {
 	clusterStatsCmd.Flags().StringVar(&flagNodeID, "node-id", "", "Comma-separated list of node filters used to limit returned information. Defaults to all nodes in the cluster.")
 }
 
-func handleDescribeClusterStats() {
-	stats, err := cluster.ClusterStats(nil, &flagNodeID, flagIncludeDefaults)
+func handleDescribeClusterStats(ctx context.Context) error {
+	stats, err := cluster.ClusterStats(ctx, nil, &flagNodeID, flagIncludeDefaults)
 	if err != nil {
-		fmt.Println("Failed to retrieve cluster information:", err)
-		return
+		return fmt.Errorf("Failed to retrieve cluster information: %w", err)
 	}
 
-	output.PrintJson(stats)
+	return output.PrintObject(stats)
 }
@@ -2,7 +2,9 @@ package describe
 
 var (
 	flagOutput       string
+	flagRepository   string
 	flagFlatSettings bool
+	flagDefinition   bool
 	flagMappings     bool
 	flagSettings     bool
 )
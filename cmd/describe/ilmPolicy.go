@@ -0,0 +1,105 @@
+package describe
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/pincher95/esctl/es"
+	"github.com/pincher95/esctl/output"
+	"github.com/spf13/cobra"
+)
+
+var describeLifecyclePolicyCmd = &cobra.Command{
+	Use:     "ilm-policy NAME",
+	Aliases: []string{"ism-policy"},
+	Short:   "Print detailed information about an index lifecycle policy",
+	Long: utils.Trim(`
+Print the phases of an ILM policy with their minimum age and actions, and the indices, data streams and templates using it.
+On OpenSearch the ISM policy is described instead, with its states, actions and transitions.`),
+	Example: utils.TrimAndIndent(`
+	# Describe a lifecycle policy.
+	esctl describe ilm-policy logs
+
+	# Include the policy definition.
+	esctl describe ilm-policy logs --definition
+	`),
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return handleDescribeLifecyclePolicy(cmd.Context(), args[0])
+	},
+}
+
+func init() {
+	describeLifecyclePolicyCmd.Flags().BoolVar(&flagDefinition, "definition", false, "Include the policy definition")
+}
+
+func handleDescribeLifecyclePolicy(ctx context.Context, name string) error {
+	policy, err := es.GetLifecyclePolicy(ctx, name)
+	if err != nil {
+		return fmt.Errorf("Failed to retrieve lifecycle policy: %w", err)
+	}
+
+	if output.Format() != output.FormatTable && !output.IsWide() {
+		return output.PrintObject(policy)
+	}
+	return printLifecyclePolicy(os.Stdout, policy)
+}
+
+// printLifecyclePolicy prints a policy as aligned 'Field: value' lines,
+// followed by its phases and what uses it.
+func printLifecyclePolicy(out io.Writer, p *es.LifecyclePolicy) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Name:\t%s\n", p.Name)
+	if p.Version > 0 {
+		fmt.Fprintf(w, "Version:\t%d\n", p.Version)
+	}
+	fmt.Fprintf(w, "Modified:\t%s\n", orNone(p.Modified))
+	if p.Description != "" {
+		fmt.Fprintf(w, "Description:\t%s\n", p.Description)
+	}
+	if p.DefaultState != "" {
+		fmt.Fprintf(w, "Default State:\t%s\n", p.DefaultState)
+	}
+	if p.Indices != nil {
+		fmt.Fprintf(w, "Indices:\t%s\n", orNone(strings.Join(p.Indices, ", ")))
+	}
+	if p.DataStreams != nil {
+		fmt.Fprintf(w, "Data Streams:\t%s\n", orNone(strings.Join(p.DataStreams, ", ")))
+	}
+	if p.Templates != nil {
+		fmt.Fprintf(w, "Templates:\t%s\n", orNone(strings.Join(p.Templates, ", ")))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	// ILM phases start after a minimum age, ISM states move on with transitions
+	w = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if p.DefaultState != "" {
+		fmt.Fprintln(out, "States:")
+		fmt.Fprintln(w, "  STATE\tACTIONS\tTRANSITIONS")
+		for _, state := range p.Phases {
+			fmt.Fprintf(w, "  %s\t%s\t%s\n", state.Name, orNone(strings.Join(state.Actions, ", ")), orNone(strings.Join(state.Transitions, "; ")))
+		}
+	} else {
+		fmt.Fprintln(out, "Phases:")
+		fmt.Fprintln(w, "  PHASE\tMIN-AGE\tACTIONS")
+		for _, phase := range p.Phases {
+			fmt.Fprintf(w, "  %s\t%s\t%s\n", phase.Name, orNone(phase.MinAge), orNone(strings.Join(phase.Actions, ", ")))
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if flagDefinition {
+		return printIndentedJSON(out, "Definition:", p.Definition)
+	}
+	return nil
+}
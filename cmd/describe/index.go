@@ -0,0 +1,199 @@
+package describe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/pincher95/esctl/es"
+	"github.com/pincher95/esctl/es/distribution"
+	"github.com/pincher95/esctl/output"
+	"github.com/spf13/cobra"
+)
+
+var describeIndexCmd = &cobra.Command{
+	Use:   "index NAME",
+	Short: "Print detailed information about an index",
+	Long: utils.Trim(`
+Print health, document counts, store size, aliases, ILM or ISM policy and phase, and shard placement per node of an index.
+NAME may be a pattern, e.g. 'logs-*', to describe every matching index.`),
+	Example: utils.TrimAndIndent(`
+	# Describe an index.
+	esctl describe index my_index
+
+	# Include the mappings and settings.
+	esctl describe index my_index --mappings --settings
+
+	# Print the description as YAML.
+	esctl describe index my_index --mappings -o yaml
+	`),
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return handleDescribeIndex(cmd.Context(), args[0])
+	},
+}
+
+func init() {
+	describeIndexCmd.Flags().BoolVar(&flagMappings, "mappings", false, "Include the index mappings")
+	describeIndexCmd.Flags().BoolVar(&flagSettings, "settings", false, "Include the index settings")
+}
+
+func handleDescribeIndex(ctx context.Context, index string) error {
+	descriptions, err := es.DescribeIndices(ctx, index, flagMappings, flagSettings)
+	if err != nil {
+		return fmt.Errorf("Failed to retrieve index details: %w", err)
+	}
+
+	if output.Format() != output.FormatTable && !output.IsWide() {
+		return output.PrintObject(descriptions)
+	}
+
+	// OpenSearch manages index lifecycles with ISM instead of ILM
+	lifecycleLabel := "ILM"
+	if distribution.Detect(ctx).IsOpenSearch() {
+		lifecycleLabel = "ISM"
+	}
+
+	for i, description := range descriptions {
+		if i > 0 {
+			fmt.Println()
+		}
+		if err := printIndexDescription(os.Stdout, description, lifecycleLabel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printIndexDescription prints an index as aligned 'Field: value' lines,
+// with its shards grouped by the node they are allocated to.
+func printIndexDescription(out io.Writer, d es.IndexDescription, lifecycleLabel string) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Name:\t%s\n", d.Name)
+	fmt.Fprintf(w, "UUID:\t%s\n", d.UUID)
+	fmt.Fprintf(w, "Health:\t%s\n", d.Health)
+	fmt.Fprintf(w, "Status:\t%s\n", d.Status)
+	if d.CreationDate != "" {
+		fmt.Fprintf(w, "Created:\t%s\n", d.CreationDate)
+	}
+	fmt.Fprintf(w, "Shards:\t%d primaries, %d replicas\n", d.Primaries, d.Replicas)
+	fmt.Fprintf(w, "Docs:\t%d (%d deleted)\n", d.DocsCount, d.DocsDeleted)
+	fmt.Fprintf(w, "Store Size:\t%s (primaries %s)\n", d.StoreSize, d.PrimaryStoreSize)
+	fmt.Fprintf(w, "Aliases:\t%s\n", orNone(strings.Join(d.Aliases, ", ")))
+
+	switch {
+	case d.Lifecycle == nil:
+	case !d.Lifecycle.Managed:
+		fmt.Fprintf(w, "%s:\tnot managed\n", lifecycleLabel)
+	default:
+		fmt.Fprintf(w, "%s:\t\n", lifecycleLabel)
+		fmt.Fprintf(w, "  Policy:\t%s\n", d.Lifecycle.Policy)
+		fmt.Fprintf(w, "  Phase:\t%s\n", d.Lifecycle.Phase)
+		fmt.Fprintf(w, "  Action:\t%s\n", d.Lifecycle.Action)
+		fmt.Fprintf(w, "  Step:\t%s\n", d.Lifecycle.Step)
+		if d.Lifecycle.FailedStep != "" {
+			fmt.Fprintf(w, "  Failed Step:\t%s\n", d.Lifecycle.FailedStep)
+		}
+		if d.Lifecycle.Age != "" {
+			fmt.Fprintf(w, "  Age:\t%s\n", d.Lifecycle.Age)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, "Shard Placement:")
+	w = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  NODE\tPRIMARIES\tREPLICAS")
+	for _, node := range shardsByNode(d.Shards) {
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", node.name, orNone(strings.Join(node.primaries, ",")), orNone(strings.Join(node.replicas, ",")))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if d.Mappings != nil {
+		if err := printIndentedJSON(out, "Mappings:", d.Mappings); err != nil {
+			return err
+		}
+	}
+	if d.Settings != nil {
+		if err := printIndentedJSON(out, "Settings:", d.Settings); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type nodeShards struct {
+	name                string
+	primaries, replicas []string
+}
+
+// shardsByNode lists the shard numbers allocated to every node, sorted by
+// node name with unassigned shards last. Shards that are not started are
+// marked with their state, e.g. '2(relocating)'.
+func shardsByNode(shards []es.IndexShard) []nodeShards {
+	const unassigned = "<unassigned>"
+
+	byNode := make(map[string]*nodeShards)
+	var names []string
+	for _, shard := range shards {
+		name := shard.Node
+		if name == "" {
+			name = unassigned
+		}
+		node, ok := byNode[name]
+		if !ok {
+			node = &nodeShards{name: name}
+			byNode[name] = node
+			names = append(names, name)
+		}
+
+		number := fmt.Sprint(shard.Shard)
+		if shard.State != "STARTED" {
+			number += "(" + strings.ToLower(shard.State) + ")"
+		}
+		if shard.Primary {
+			node.primaries = append(node.primaries, number)
+		} else {
+			node.replicas = append(node.replicas, number)
+		}
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		if (names[i] == unassigned) != (names[j] == unassigned) {
+			return names[j] == unassigned
+		}
+		return names[i] < names[j]
+	})
+
+	result := make([]nodeShards, 0, len(names))
+	for _, name := range names {
+		result = append(result, *byNode[name])
+	}
+	return result
+}
+
+func printIndentedJSON(out io.Writer, title string, data interface{}) error {
+	jsonData, err := json.MarshalIndent(data, "  ", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to generate pretty JSON: %w", err)
+	}
+	fmt.Fprintf(out, "%s\n  %s\n", title, jsonData)
+	return nil
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
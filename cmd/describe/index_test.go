@@ -0,0 +1,27 @@
+package describe
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pincher95/esctl/es"
+)
+
+func TestShardsByNode(t *testing.T) {
+	shards := []es.IndexShard{
+		{Shard: 0, Primary: true, State: "STARTED", Node: "node-2"},
+		{Shard: 0, Primary: false, State: "UNASSIGNED"},
+		{Shard: 1, Primary: true, State: "RELOCATING", Node: "node-1"},
+		{Shard: 1, Primary: false, State: "STARTED", Node: "node-2"},
+	}
+
+	want := []nodeShards{
+		{name: "node-1", primaries: []string{"1(relocating)"}},
+		{name: "node-2", primaries: []string{"0"}, replicas: []string{"1"}},
+		{name: "<unassigned>", replicas: []string{"0(unassigned)"}},
+	}
+
+	if got := shardsByNode(shards); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
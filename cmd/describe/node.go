@@ -0,0 +1,147 @@
+package describe
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/pincher95/esctl/es"
+	"github.com/pincher95/esctl/output"
+	"github.com/spf13/cobra"
+)
+
+var describeNodeCmd = &cobra.Command{
+	Use:   "node NAME",
+	Short: "Print detailed information about a node",
+	Long: utils.Trim(`
+Print roles, attributes, JVM heap, OS, disk usage against the allocation watermarks, the shards hosted per index,
+and the thread pools with active or queued tasks or rejections of a node.
+NAME may be a node name, ID, address or pattern, e.g. 'es-data-*', to describe every matching node.`),
+	Example: utils.TrimAndIndent(`
+		# Describe a node.
+		esctl describe node es-data-0
+
+		# Print the description as JSON.
+		esctl describe node es-data-0 -o json
+		`),
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return handleDescribeNode(cmd.Context(), args[0])
+	},
+}
+
+func handleDescribeNode(ctx context.Context, node string) error {
+	descriptions, err := es.DescribeNodes(ctx, node)
+	if err != nil {
+		return fmt.Errorf("Failed to retrieve node details: %w", err)
+	}
+
+	if output.Format() != output.FormatTable && !output.IsWide() {
+		return output.PrintObject(descriptions)
+	}
+
+	for i, description := range descriptions {
+		if i > 0 {
+			fmt.Println()
+		}
+		if err := printNodeDescription(os.Stdout, description); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printNodeDescription prints a node as aligned 'Field: value' lines,
+// followed by tables of its shards and busy thread pools.
+func printNodeDescription(out io.Writer, d es.NodeDescription) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Name:\t%s\n", d.Name)
+	fmt.Fprintf(w, "ID:\t%s\n", d.ID)
+	fmt.Fprintf(w, "Address:\t%s (%s)\n", d.TransportAddress, d.Host)
+	fmt.Fprintf(w, "Version:\t%s\n", d.Version)
+	fmt.Fprintf(w, "Roles:\t%s\n", orNone(strings.Join(d.Roles, ", ")))
+	fmt.Fprintf(w, "Attributes:\t%s\n", orNone(formatAttributes(d.Attributes)))
+
+	fmt.Fprintf(w, "OS:\t\n")
+	fmt.Fprintf(w, "  Name:\t%s %s (%s)\n", d.OS.Name, d.OS.Version, d.OS.Arch)
+	fmt.Fprintf(w, "  Processors:\t%d\n", d.OS.AvailableProcessors)
+	fmt.Fprintf(w, "  CPU:\t%d%%\n", d.OS.CPUPercent)
+	if len(d.OS.LoadAverage) > 0 {
+		fmt.Fprintf(w, "  Load Average:\t%.2f, %.2f, %.2f\n", d.OS.LoadAverage["1m"], d.OS.LoadAverage["5m"], d.OS.LoadAverage["15m"])
+	}
+	fmt.Fprintf(w, "  Memory:\t%s / %s (%d%%)\n", utils.FormatBytes(d.OS.MemUsedBytes), utils.FormatBytes(d.OS.MemTotalBytes), d.OS.MemUsedPercent)
+
+	fmt.Fprintf(w, "JVM:\t\n")
+	fmt.Fprintf(w, "  Version:\t%s (%s)\n", d.JVM.Version, d.JVM.VMName)
+	fmt.Fprintf(w, "  Uptime:\t%s\n", (time.Duration(d.JVM.UptimeMillis) * time.Millisecond).Truncate(time.Second))
+	fmt.Fprintf(w, "  Heap:\t%s / %s (%d%%)\n", utils.FormatBytes(d.JVM.HeapUsedBytes), utils.FormatBytes(d.JVM.HeapMaxBytes), d.JVM.HeapUsedPercent)
+	for _, name := range sortedKeys(d.JVM.GC) {
+		gc := d.JVM.GC[name]
+		fmt.Fprintf(w, "  GC %s:\t%d collections, %s\n", name, gc.Count, time.Duration(gc.TimeMillis)*time.Millisecond)
+	}
+
+	fmt.Fprintf(w, "Disk:\t\n")
+	fmt.Fprintf(w, "  Used:\t%s / %s (%.1f%%)\n", utils.FormatBytes(d.Disk.TotalBytes-d.Disk.AvailableBytes), utils.FormatBytes(d.Disk.TotalBytes), d.Disk.UsedPercent)
+	fmt.Fprintf(w, "  Available:\t%s\n", utils.FormatBytes(d.Disk.AvailableBytes))
+	if d.Disk.WatermarkLow != "" || d.Disk.WatermarkHigh != "" || d.Disk.WatermarkFloodStage != "" {
+		fmt.Fprintf(w, "  Watermarks:\tlow %s, high %s, flood stage %s\n", orNone(d.Disk.WatermarkLow), orNone(d.Disk.WatermarkHigh), orNone(d.Disk.WatermarkFloodStage))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, "Shards:")
+	w = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  INDEX\tPRIMARIES\tREPLICAS\tDOCS\tSTORE")
+	for _, index := range d.Indices {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%d\t%s\n", index.Index, orNone(joinInts(index.Primaries)), orNone(joinInts(index.Replicas)), index.Docs, utils.FormatBytes(index.StoreBytes))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(d.ThreadPools) == 0 {
+		fmt.Fprintln(out, "Hot Thread Pools:  <none>")
+		return nil
+	}
+	fmt.Fprintln(out, "Hot Thread Pools:")
+	w = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  NAME\tTHREADS\tACTIVE\tQUEUE\tREJECTED\tCOMPLETED")
+	for _, pool := range d.ThreadPools {
+		fmt.Fprintf(w, "  %s\t%d\t%d\t%d\t%d\t%d\n", pool.Name, pool.Threads, pool.Active, pool.Queue, pool.Rejected, pool.Completed)
+	}
+	return w.Flush()
+}
+
+// formatAttributes formats node attributes as sorted 'key=value' pairs.
+func formatAttributes(attributes map[string]string) string {
+	pairs := make([]string, 0, len(attributes))
+	for _, key := range sortedKeys(attributes) {
+		pairs = append(pairs, key+"="+attributes[key])
+	}
+	return strings.Join(pairs, ", ")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func joinInts(numbers []int) string {
+	s := make([]string, len(numbers))
+	for i, n := range numbers {
+		s[i] = fmt.Sprint(n)
+	}
+	return strings.Join(s, ",")
+}
@@ -0,0 +1,105 @@
+package describe
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/pincher95/esctl/es"
+	"github.com/pincher95/esctl/output"
+	"github.com/spf13/cobra"
+)
+
+var describeSnapshotCmd = &cobra.Command{
+	Use:   "snapshot NAME",
+	Short: "Print detailed information about a snapshot",
+	Long: utils.Trim(`
+Print state, timing, size, shard counts, the indices and data streams, and any shard failures of a snapshot.`),
+	Example: utils.TrimAndIndent(`
+	# Describe a snapshot.
+	esctl describe snapshot nightly-2024.05.01 --repo my_repo
+
+	# Print the description as JSON.
+	esctl describe snapshot nightly-2024.05.01 --repo my_repo -o json
+	`),
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return handleDescribeSnapshot(cmd.Context(), args[0])
+	},
+}
+
+func init() {
+	describeSnapshotCmd.Flags().StringVarP(&flagRepository, "repo", "r", "", "Name of the snapshot repository")
+	_ = describeSnapshotCmd.MarkFlagRequired("repo")
+}
+
+func handleDescribeSnapshot(ctx context.Context, name string) error {
+	description, err := es.DescribeSnapshot(ctx, flagRepository, name)
+	if err != nil {
+		return fmt.Errorf("Failed to retrieve snapshot details: %w", err)
+	}
+
+	if output.Format() != output.FormatTable && !output.IsWide() {
+		return output.PrintObject(description)
+	}
+	return printSnapshotDescription(os.Stdout, description)
+}
+
+// printSnapshotDescription prints a snapshot as aligned 'Field: value' lines,
+// followed by the size of every index and the shard failures.
+func printSnapshotDescription(out io.Writer, d *es.SnapshotDescription) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Name:\t%s\n", d.Snapshot.Snapshot)
+	fmt.Fprintf(w, "UUID:\t%s\n", d.UUID)
+	fmt.Fprintf(w, "Repository:\t%s\n", d.Repository)
+	fmt.Fprintf(w, "State:\t%s\n", d.State)
+	if d.Reason != "" {
+		fmt.Fprintf(w, "Reason:\t%s\n", d.Reason)
+	}
+	if d.Version != "" {
+		fmt.Fprintf(w, "Version:\t%s\n", d.Version)
+	}
+	fmt.Fprintf(w, "Started:\t%s\n", orNone(d.StartTime))
+	fmt.Fprintf(w, "Ended:\t%s\n", orNone(d.EndTime))
+	fmt.Fprintf(w, "Duration:\t%s\n", (time.Duration(d.DurationInMillis) * time.Millisecond).Truncate(time.Second))
+	fmt.Fprintf(w, "Size:\t%s (%d files)\n", utils.FormatBytes(d.Size.SizeInBytes), d.Size.FileCount)
+	fmt.Fprintf(w, "Shards:\t%d total, %d successful, %d failed\n", d.Shards.Total, d.Shards.Successful, d.Shards.Failed)
+	fmt.Fprintf(w, "Global State:\t%t\n", d.IncludeGlobalState)
+	fmt.Fprintf(w, "Data Streams:\t%s\n", orNone(strings.Join(d.DataStreams, ", ")))
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	indices := append([]string(nil), d.Indices...)
+	sort.Strings(indices)
+	fmt.Fprintf(out, "Indices (%d):\n", len(indices))
+	w = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  INDEX\tSIZE\tFILES")
+	for _, index := range indices {
+		size := d.IndexSizes[index]
+		fmt.Fprintf(w, "  %s\t%s\t%d\n", index, utils.FormatBytes(size.SizeInBytes), size.FileCount)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(d.Failures) > 0 {
+		fmt.Fprintln(out, "Failures:")
+		w = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "  INDEX\tSHARD\tNODE\tSTATUS\tREASON")
+		for _, failure := range d.Failures {
+			fmt.Fprintf(w, "  %s\t%d\t%s\t%s\t%s\n", failure.Index, failure.ShardID, orNone(failure.NodeID), failure.Status, strings.Join(strings.Fields(failure.Reason), " "))
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
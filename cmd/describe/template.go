@@ -0,0 +1,91 @@
+package describe
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/pincher95/esctl/es"
+	"github.com/pincher95/esctl/output"
+	"github.com/spf13/cobra"
+)
+
+var describeTemplateCmd = &cobra.Command{
+	Use:     "template NAME",
+	Aliases: []string{"index-template"},
+	Short:   "Print detailed information about an index template",
+	Long: utils.Trim(`
+Print the index patterns, priority and component templates of a composable index template, the other templates
+overlapping with its patterns, and the settings, mappings and aliases it resolves to once merged with its component templates.`),
+	Example: utils.TrimAndIndent(`
+	# Describe an index template.
+	esctl describe template logs
+
+	# Print the resolved template as YAML.
+	esctl describe template logs -o yaml
+	`),
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return handleDescribeTemplate(cmd.Context(), args[0])
+	},
+}
+
+func handleDescribeTemplate(ctx context.Context, name string) error {
+	description, err := es.DescribeIndexTemplate(ctx, name)
+	if err != nil {
+		return fmt.Errorf("Failed to retrieve index template: %w", err)
+	}
+
+	if output.Format() != output.FormatTable && !output.IsWide() {
+		return output.PrintObject(description)
+	}
+	return printTemplateDescription(os.Stdout, description)
+}
+
+// printTemplateDescription prints an index template as aligned 'Field: value'
+// lines, followed by its resolved settings, mappings and aliases.
+func printTemplateDescription(out io.Writer, d *es.IndexTemplateDescription) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Name:\t%s\n", d.Name)
+	fmt.Fprintf(w, "Index Patterns:\t%s\n", strings.Join(d.IndexPatterns, ", "))
+	fmt.Fprintf(w, "Priority:\t%d\n", d.Priority)
+	if d.Version > 0 {
+		fmt.Fprintf(w, "Version:\t%d\n", d.Version)
+	}
+	if description := d.Description(); description != "" {
+		fmt.Fprintf(w, "Description:\t%s\n", description)
+	}
+	fmt.Fprintf(w, "Composed Of:\t%s\n", orNone(strings.Join(d.ComposedOf, ", ")))
+	fmt.Fprintf(w, "Data Stream:\t%t\n", d.DataStream)
+
+	overlapping := make([]string, 0, len(d.Overlapping))
+	for _, o := range d.Overlapping {
+		overlapping = append(overlapping, fmt.Sprintf("%s (%s)", o.Name, strings.Join(o.IndexPatterns, ", ")))
+	}
+	fmt.Fprintf(w, "Overlapping:\t%s\n", orNone(strings.Join(overlapping, ", ")))
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	for _, section := range []struct {
+		title string
+		data  interface{}
+	}{
+		{"Settings:", d.Resolved.Settings},
+		{"Mappings:", d.Resolved.Mappings},
+		{"Aliases:", d.Resolved.Aliases},
+	} {
+		if section.data == nil {
+			continue
+		}
+		if err := printIndentedJSON(out, section.title, section.data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
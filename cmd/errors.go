@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pincher95/esctl/cmd/config"
+	"github.com/pincher95/esctl/internal/client"
+	"github.com/pincher95/esctl/output"
+)
+
+// Exit codes, so scripts can tell why a command failed.
+const (
+	exitOK            = 0
+	exitError         = 1
+	exitConfig        = 2
+	exitConnection    = 3
+	exitElasticsearch = 4
+	exitNotFound      = 5
+	exitInterrupted   = 130
+)
+
+// exitCode maps an error returned by a command to the process exit code.
+func exitCode(err error) int {
+	var configErr *config.Error
+	var responseErr *client.ResponseError
+	var notFoundErr *client.NotFoundError
+	var readOnlyErr *client.ReadOnlyError
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return exitInterrupted
+	case errors.As(err, &configErr), errors.As(err, &readOnlyErr):
+		return exitConfig
+	case errors.As(err, &responseErr):
+		if responseErr.NotFound() {
+			return exitNotFound
+		}
+		return exitElasticsearch
+	case errors.As(err, &notFoundErr):
+		return exitNotFound
+	case client.IsConnectionError(err):
+		return exitConnection
+	default:
+		return exitError
+	}
+}
+
+// printError is the single place errors of commands are reported. Errors
+// returned by Elasticsearch are followed by their details, or printed as the
+// Elasticsearch error envelope with '-o json'.
+func printError(err error) {
+	var responseErr *client.ResponseError
+	isResponseErr := errors.As(err, &responseErr)
+
+	if output.Format() == output.FormatJSON {
+		var data interface{} = map[string]interface{}{"error": map[string]string{"reason": err.Error()}}
+		if isResponseErr {
+			data = responseErr
+		}
+		encoder := json.NewEncoder(os.Stderr)
+		encoder.SetIndent("", "  ")
+		if encoder.Encode(data) == nil {
+			return
+		}
+	}
+
+	fmt.Fprintln(os.Stderr, "Error:", err)
+	if isResponseErr {
+		writeErrorDetails(os.Stderr, responseErr)
+	}
+}
+
+// writeErrorDetails prints the status, affected index and shard, and the root
+// causes and caused_by chain of an Elasticsearch error.
+func writeErrorDetails(w io.Writer, err *client.ResponseError) {
+	cause := err.Cause
+	fmt.Fprintf(w, "  status: %s\n", err.Status)
+	if cause.Index != "" {
+		fmt.Fprintf(w, "  index: %s\n", cause.Index)
+	}
+	if cause.Shard != "" {
+		fmt.Fprintf(w, "  shard: %s\n", cause.Shard)
+	}
+	for _, rootCause := range cause.RootCause {
+		// The root cause usually repeats the error itself
+		if rootCause.String() == cause.String() && rootCause.CausedBy == nil {
+			continue
+		}
+		fmt.Fprintf(w, "  root cause: %s\n", rootCause)
+		writeCausedBy(w, rootCause.CausedBy, "    ")
+	}
+	writeCausedBy(w, cause.CausedBy, "  ")
+}
+
+func writeCausedBy(w io.Writer, cause *client.ErrorCause, indent string) {
+	for ; cause != nil; cause = cause.CausedBy {
+		fmt.Fprintf(w, "%scaused by: %s\n", indent, cause)
+		indent += "  "
+	}
+}
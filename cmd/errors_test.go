@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/pincher95/esctl/cmd/config"
+	"github.com/pincher95/esctl/internal/client"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"generic", errors.New("header 'x' is not a valid column"), exitError},
+		{"config", config.Errorf("no contexts defined in the configuration"), exitConfig},
+		{"connection", fmt.Errorf("Failed to retrieve indices: %w", &url.Error{Op: "Get", URL: "http://localhost:9200", Err: errors.New("connection refused")}), exitConnection},
+		{"elasticsearch", fmt.Errorf("Failed to retrieve indices: %w", &client.ResponseError{StatusCode: http.StatusBadRequest}), exitElasticsearch},
+		{"not found", &client.ResponseError{StatusCode: http.StatusNotFound}, exitNotFound},
+		{"node not found", fmt.Errorf("Failed to retrieve node details: %w", &client.NotFoundError{Kind: "node", Name: "es-1"}), exitNotFound},
+		{"read-only", fmt.Errorf("Failed to delete 1 index: %w", &client.ReadOnlyError{Request: "DELETE logs-1"}), exitConfig},
+		{"interrupted", &url.Error{Op: "Get", URL: "http://localhost:9200", Err: context.Canceled}, exitInterrupted},
+	}
+
+	for _, test := range tests {
+		if got := exitCode(test.err); got != test.want {
+			t.Errorf("%s: got exit code %d, want %d", test.name, got, test.want)
+		}
+	}
+}
+
+func TestWriteErrorDetails(t *testing.T) {
+	err := &client.ResponseError{
+		StatusCode: http.StatusBadRequest,
+		Status:     "400 Bad Request",
+		Cause: client.ErrorCause{
+			Type:   "search_phase_execution_exception",
+			Reason: "all shards failed",
+			RootCause: []client.ErrorCause{
+				{Type: "search_phase_execution_exception", Reason: "all shards failed"},
+				{Type: "query_shard_exception", Reason: "failed to create query", Index: "logs"},
+			},
+			CausedBy: &client.ErrorCause{
+				Type:     "illegal_argument_exception",
+				Reason:   "bad field",
+				CausedBy: &client.ErrorCause{Type: "number_format_exception", Reason: "not a number"},
+			},
+		},
+	}
+
+	var b strings.Builder
+	writeErrorDetails(&b, err)
+
+	want := `  status: 400 Bad Request
+  root cause: query_shard_exception: failed to create query
+  caused by: illegal_argument_exception: bad field
+    caused by: number_format_exception: not a number
+`
+	if b.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", b.String(), want)
+	}
+}
@@ -0,0 +1,27 @@
+package explain
+
+import (
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+var explainCmd = &cobra.Command{
+	Use:   "explain",
+	Short: "Explain the state of Elasticsearch entities",
+	Long: utils.Trim(`
+The 'explain' command allows you to find out why an Elasticsearch entity is in its current state.
+
+Available Entities:
+  - ilm: Explain the lifecycle state of indices, e.g. why they are stuck in a step.`),
+	Example: utils.TrimAndIndent(`
+# Explain the lifecycle state of the indices stuck in a failed step.
+esctl explain ilm 'logs-*' --only-errors`),
+}
+
+func init() {
+	explainCmd.AddCommand(explainLifecycleCmd)
+}
+
+func Cmd() *cobra.Command {
+	return explainCmd
+}
@@ -0,0 +1,6 @@
+package explain
+
+var (
+	flagOnlyErrors  bool
+	flagOnlyManaged bool
+)
@@ -0,0 +1,119 @@
+package explain
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/pincher95/esctl/es"
+	"github.com/pincher95/esctl/es/distribution"
+	"github.com/pincher95/esctl/output"
+	"github.com/spf13/cobra"
+)
+
+var explainLifecycleCmd = &cobra.Command{
+	Use:     "ilm INDEX",
+	Aliases: []string{"ism"},
+	Short:   "Explain the lifecycle state of indices",
+	Long: utils.Trim(`
+Explain the ILM state of the indices matching INDEX: policy, phase, action and step, and for indices stuck in a step
+the failed step, the number of retries and the reason reported in the step info.
+On OpenSearch the ISM state is explained instead, with the ISM state as phase.
+Use 'esctl update ilm retry' to re-run failed steps.`),
+	Example: utils.TrimAndIndent(`
+	# Explain the lifecycle state of an index.
+	esctl explain ilm logs-000001
+
+	# Explain why indices are stuck in a failed step.
+	esctl explain ilm 'logs-*' --only-errors
+	`),
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return handleExplainLifecycle(cmd.Context(), args[0])
+	},
+}
+
+func init() {
+	explainLifecycleCmd.Flags().BoolVar(&flagOnlyErrors, "only-errors", false, "Only show indices stuck in a failed step")
+	explainLifecycleCmd.Flags().BoolVar(&flagOnlyManaged, "only-managed", false, "Only show indices managed by a policy")
+}
+
+func handleExplainLifecycle(ctx context.Context, index string) error {
+	lifecycles, err := es.ExplainLifecycle(ctx, index, flagOnlyErrors, flagOnlyManaged)
+	if err != nil {
+		return fmt.Errorf("Failed to explain lifecycle state: %w", err)
+	}
+
+	if output.Format() != output.FormatTable && !output.IsWide() {
+		return output.PrintObject(lifecycles)
+	}
+
+	if len(lifecycles) == 0 {
+		fmt.Fprintf(os.Stderr, "No matching index for %s\n", index)
+		return nil
+	}
+
+	// OpenSearch moves indices through the states of an ISM policy instead of phases
+	phaseLabel := "Phase"
+	if distribution.Detect(ctx).IsOpenSearch() {
+		phaseLabel = "State"
+	}
+
+	for i, lifecycle := range lifecycles {
+		if i > 0 {
+			fmt.Println()
+		}
+		if err := printLifecycle(os.Stdout, lifecycle, phaseLabel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printLifecycle prints the lifecycle state of an index as aligned
+// 'Field: value' lines, with the failure details of a failed step.
+func printLifecycle(out io.Writer, l es.IndexLifecycle, phaseLabel string) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Index:\t%s\n", l.Index)
+	if !l.Managed {
+		fmt.Fprintf(w, "Managed:\tfalse\n")
+		return w.Flush()
+	}
+
+	fmt.Fprintf(w, "Managed:\ttrue\n")
+	fmt.Fprintf(w, "Policy:\t%s\n", l.Policy)
+	if l.Age != "" {
+		fmt.Fprintf(w, "Age:\t%s\n", l.Age)
+	}
+	fmt.Fprintf(w, "%s:\t%s\n", phaseLabel, since(l.Phase, l.PhaseTimeMillis))
+	fmt.Fprintf(w, "Action:\t%s\n", since(l.Action, l.ActionTimeMillis))
+	fmt.Fprintf(w, "Step:\t%s\n", since(l.Step, l.StepTimeMillis))
+	if l.Failed() {
+		fmt.Fprintf(w, "Failed Step:\t%s\n", l.FailedStep)
+		fmt.Fprintf(w, "Retries:\t%d\n", l.FailedStepRetryCount)
+		if l.AutoRetryableError {
+			fmt.Fprintf(w, "Auto Retryable:\ttrue\n")
+		}
+	}
+	if info := l.StepInfoSummary(); info != "" {
+		fmt.Fprintf(w, "Step Info:\t%s\n", info)
+	}
+	return w.Flush()
+}
+
+// since appends when a phase, action or step was entered, e.g. 'hot (since
+// 2024-05-01T10:00:00Z)'.
+func since(name string, millis int64) string {
+	if name == "" {
+		return "<none>"
+	}
+	if millis <= 0 {
+		return name
+	}
+	return fmt.Sprintf("%s (since %s)", name, time.UnixMilli(millis).UTC().Format(time.RFC3339))
+}
@@ -1,9 +1,8 @@
 package get
 
 import (
+	"context"
 	"fmt"
-	"os"
-	"time"
 
 	"github.com/pincher95/esctl/cmd/config"
 	"github.com/pincher95/esctl/cmd/utils"
@@ -25,21 +24,15 @@ var getAliasesCmd = &cobra.Command{
 	# Retrieve aliases for a specific index.
 	esctl get aliases --index my_index
 	`),
-	Run: func(cmd *cobra.Command, args []string) {
-		config := config.ParseConfigFile()
-
-		// If --watch is NOT set, just run once
-		if !flagRefresh {
-			handleAliasLogic(*config)
-			return
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := config.ParseConfigFile()
+		if err != nil {
+			return err
 		}
 
-		// If --watch is set, run in a loop
-		for {
-			clearScreen() // optional, to mimic "watch" clearing
-			handleAliasLogic(*config)
-			time.Sleep(flagRefreshInterval)
-		}
+		return showTable(cmd, func(ctx context.Context) (*output.Table, error) {
+			return aliasTable(ctx, *config)
+		}, "ALIAS")
 	},
 }
 
@@ -56,20 +49,18 @@ var aliasColumns = []output.ColumnDefaults{
 	{Header: "IS_WRITE_INDEX", Type: output.Text},
 }
 
-func handleAliasLogic(conf config.Config) {
-	aliases, err := es.GetAliases(flagIndex)
+func aliasTable(ctx context.Context, conf config.Config) (*output.Table, error) {
+	aliases, err := es.GetAliases(ctx, flagIndex)
 	if err != nil {
-		fmt.Fprintln(os.Stderr, "Failed to retrieve aliases:", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("Failed to retrieve aliases: %w", err)
 	}
 
 	columnDefs, err := getColumnDefs(conf, "alias", aliasColumns)
 	if err != nil {
-		fmt.Fprintln(os.Stderr, "Failed to get column definitions:", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("Failed to get column definitions: %w", err)
 	}
 
-	data := [][]string{}
+	table := output.NewTable(columnDefs, "ALIAS")
 
 	for alias, index := range aliases {
 		rowData := map[string]string{
@@ -78,14 +69,8 @@ func handleAliasLogic(conf config.Config) {
 			// "FILTER": alias,
 		}
 
-		row := make([]string, len(columnDefs))
-		for i, colDef := range columnDefs {
-			row[i] = rowData[colDef.Header]
-		}
-		data = append(data, row)
+		table.AddRow(map[string]string{"alias": alias, "index": index}, rowData)
 	}
 
-	sortCols := output.ParseSortColumns(flagSortBy)
-
-	output.PrintTable(columnDefs, data, sortCols)
+	return table, filterRows(table, aliasColumns)
 }
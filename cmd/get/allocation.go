@@ -1,9 +1,9 @@
 package get
 
 import (
+	"context"
 	"fmt"
 	"strconv"
-	"time"
 
 	"github.com/pincher95/esctl/cmd/config"
 	"github.com/pincher95/esctl/cmd/utils"
@@ -27,22 +27,14 @@ var getAllocationCmd = &cobra.Command{
 	esctl get allocation --node my_node
 	`),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		config := config.ParseConfigFile()
-
-		// If --watch is NOT set, just run once
-		if !flagRefresh {
-			return handleAllocationLogic(*config)
+		config, err := config.ParseConfigFile()
+		if err != nil {
+			return err
 		}
 
-		// If --watch is set, run in a loop
-		for {
-			clearScreen() // optional, to mimic "watch" clearing
-			err := handleAllocationLogic(*config)
-			if err != nil {
-				return err
-			}
-			time.Sleep(flagRefreshInterval)
-		}
+		return showTable(cmd, func(ctx context.Context) (*output.Table, error) {
+			return allocationTable(ctx, *config)
+		}, "SHARDS")
 	},
 }
 
@@ -53,28 +45,28 @@ func init() {
 
 var allocationColumns = []output.ColumnDefaults{
 	{Header: "SHARDS", Type: output.Number},
-	{Header: "DISK-INDICES", Type: output.Text},
-	{Header: "DISK-USED", Type: output.Text},
-	{Header: "DISK-AVAIL", Type: output.Text},
-	{Header: "DISK-TOTAL", Type: output.Number},
-	{Header: "DISK-PERCENT", Type: output.Number},
-	{Header: "HOST", Type: output.Number},
-	{Header: "IP", Type: output.Date},
-	{Header: "NODE", Type: output.DataSize},
+	{Header: "DISK-INDICES", Type: output.DataSize},
+	{Header: "DISK-USED", Type: output.DataSize},
+	{Header: "DISK-AVAIL", Type: output.DataSize},
+	{Header: "DISK-TOTAL", Type: output.DataSize},
+	{Header: "DISK-PERCENT", Type: output.Percent},
+	{Header: "HOST", Type: output.Text},
+	{Header: "IP", Type: output.Text},
+	{Header: "NODE", Type: output.Text},
 }
 
-func handleAllocationLogic(conf config.Config) error {
-	allocations, err := cat.CatAllocation(nil, &flagNodeID, &flagBytes)
+func allocationTable(ctx context.Context, conf config.Config) (*output.Table, error) {
+	allocations, err := cat.CatAllocation(ctx, nil, &flagNodeID, &flagBytes)
 	if err != nil {
-		return fmt.Errorf("Failed to retrieve allocation: %v", err)
+		return nil, fmt.Errorf("Failed to retrieve allocation: %w", err)
 	}
 
 	columnDefs, err := getColumnDefs(conf, "shards", allocationColumns)
 	if err != nil {
-		return fmt.Errorf("Failed to get column definitions: %v", err)
+		return nil, fmt.Errorf("Failed to get column definitions: %w", err)
 	}
 
-	data := [][]string{}
+	table := output.NewTable(columnDefs, "NODE")
 
 	for _, allocation := range allocations {
 		rowData := map[string]string{
@@ -89,20 +81,9 @@ func handleAllocationLogic(conf config.Config) error {
 			"NODE":         allocation.Node,
 		}
 
-		row := make([]string, len(columnDefs))
-		for i, colDef := range columnDefs {
-			row[i] = rowData[colDef.Header]
-		}
-		data = append(data, row)
+		table.AddRow(allocation, rowData)
 	}
 
-	if len(flagSortBy) > 0 {
-		sortCols := output.ParseSortColumns(flagSortBy)
-		output.PrintTable(columnDefs, data, sortCols)
-	} else {
-		sortCols := output.ParseSortColumns("SHARDS")
-		output.PrintTable(columnDefs, data, sortCols)
-	}
+	return table, filterRows(table, allocationColumns)
 
-	return nil
 }
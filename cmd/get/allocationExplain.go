@@ -1,6 +1,7 @@
 package get
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -28,17 +29,22 @@ var getAllocationExplainCmd = &cobra.Command{
 
 		// If --watch is NOT set, just run once
 		if !flagRefresh {
-			return handleAllocationExplainLogic()
+			return handleAllocationExplainLogic(cmd.Context())
 		}
 
-		// If --watch is set, run in a loop
+		// If --watch is set, print it again every interval
 		for {
-			clearScreen() // optional, to mimic "watch" clearing
-			err := handleAllocationExplainLogic()
+			err := handleAllocationExplainLogic(cmd.Context())
 			if err != nil {
 				return err
 			}
-			time.Sleep(flagRefreshInterval)
+
+			select {
+			case <-time.After(flagRefreshInterval):
+			case <-cmd.Context().Done():
+				return nil
+			}
+			fmt.Println()
 		}
 	},
 }
@@ -48,13 +54,11 @@ func init() {
 	getAllocationExplainCmd.Flags().BoolVar(&flagIncludeYesDecisions, "include-yes-decisions", false, "YES decisions in explanation")
 }
 
-func handleAllocationExplainLogic() error {
-	allocationsExplain, err := cluster.ClusterAllocationExplain(nil, flagIncludeDiskInfo, flagIncludeYesDecisions)
+func handleAllocationExplainLogic(ctx context.Context) error {
+	allocationsExplain, err := cluster.ClusterAllocationExplain(ctx, nil, flagIncludeDiskInfo, flagIncludeYesDecisions)
 	if err != nil {
-		return fmt.Errorf("Failed to retrieve allocation explain%v", err)
+		return fmt.Errorf("Failed to retrieve allocation explain: %w", err)
 	}
 
-	output.PrintJson(allocationsExplain)
-
-	return nil
+	return output.PrintObject(allocationsExplain)
 }
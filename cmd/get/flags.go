@@ -8,7 +8,11 @@ var (
 	flagIndex               string
 	flagNode                string
 	flagNodeID              string
+	flagRepository          string
+	flagSnapshot            string
+	flagTemplate            string
 	flagSortBy              string
+	flagWhere               string
 	flagBytes               string
 	flagTime                string
 	flagRefreshInterval     time.Duration
@@ -20,6 +24,7 @@ var (
 	flagStarted             bool
 	flagUnassigned          bool
 	flagRefresh             bool
+	flagActiveOnly          bool
 	flagIncludeDiskInfo     bool
 	flagIncludeYesDecisions bool
 )
@@ -7,6 +7,7 @@ import (
 
 	"github.com/pincher95/esctl/cmd/config"
 	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/pincher95/esctl/internal/tui"
 	"github.com/pincher95/esctl/output"
 	"github.com/spf13/cobra"
 )
@@ -25,7 +26,14 @@ Available Entities:
   - tasks: List all tasks in the Elasticsearch cluster.
 	- allocation: List allocation in the Elasticsearch cluster.
 	- plugins: List all plugins in the Elasticsearch cluster.
-	- explain: List allocation explain in the Elasticsearch cluster.`),
+	- explain: List allocation explain in the Elasticsearch cluster.
+  - repositories: List all snapshot repositories.
+  - snapshots: List the snapshots of a repository.
+  - snapshot-progress: List the progress of running snapshots and restores per shard.
+  - recovery: List shard recoveries with their progress and throughput.
+  - ilm-policies: List index lifecycle policies, ISM policies on OpenSearch.
+  - templates: List composable index templates with their patterns and priority.
+  - component-templates: List component templates and the index templates using them.`),
 	Example: utils.TrimAndIndent(`
 #Retrieve a list of all nodes in the Elasticsearch cluster.
 esctl get nodes
@@ -49,11 +57,15 @@ esctl get aliases
 esctl get tasks --actions 'index*' --actions '*search*'
 
 #Retrieve all tasks.
-esctl get tasks`),
+esctl get tasks
+
+#Retrieve the snapshots of a repository.
+esctl get snapshots --repo my_repo`),
 }
 
 func init() {
 	getCmd.PersistentFlags().StringVarP(&flagSortBy, "sort-by", "s", "", "Columns to sort by (comma-separated), e.g. 'NAME:desc,HEAP-PERCENT:asc'")
+	getCmd.PersistentFlags().StringVar(&flagWhere, "where", "", "Only show rows matching all conditions (comma-separated), e.g. 'STORE-SIZE>10gb,HEALTH!=green,INDEX=~^logs-'")
 	getCmd.PersistentFlags().StringSliceVarP(&flagColumns, "columns", "c", []string{}, "Columns to display (comma-separated) or 'all'")
 	getCmd.PersistentFlags().BoolVarP(&flagRefresh, "watch", "w", false, "Continuously watch the output")
 	getCmd.PersistentFlags().DurationVar(&flagRefreshInterval, "interval", 5*time.Second, "Interval between consecutive fetches")
@@ -66,6 +78,13 @@ func init() {
 	getCmd.AddCommand(getAllocationCmd)
 	getCmd.AddCommand(getPluginsCmd)
 	getCmd.AddCommand(getAllocationExplainCmd)
+	getCmd.AddCommand(getRepositoriesCmd)
+	getCmd.AddCommand(getSnapshotsCmd)
+	getCmd.AddCommand(getSnapshotProgressCmd)
+	getCmd.AddCommand(getRecoveryCmd)
+	getCmd.AddCommand(getLifecyclePoliciesCmd)
+	getCmd.AddCommand(getTemplatesCmd)
+	getCmd.AddCommand(getComponentTemplatesCmd)
 }
 
 func Cmd() *cobra.Command {
@@ -98,16 +117,132 @@ func getColumnDefs(conf config.Config, entity string, defaultColumns []output.Co
 			}
 		}
 		return buildColumnDefs(flagColumns, defaultColumns)
+	} else if output.IsWide() {
+		return defaultColumns, nil
 	} else {
 		entityConfig, ok := conf.Entities[entity]
 		if !ok || len(entityConfig.Columns) == 0 {
-			return defaultColumns, nil
+			return narrowColumns(defaultColumns), nil
 		}
 		return buildColumnDefs(entityConfig.Columns, defaultColumns)
 	}
 }
 
-func clearScreen() {
-	// Move cursor to top-left and clear screen
-	fmt.Print("\033[?1049h\033[H\033[?25l")
+// narrowColumns drops the columns only shown in wide output.
+func narrowColumns(columns []output.ColumnDefaults) []output.ColumnDefaults {
+	narrow := make([]output.ColumnDefaults, 0, len(columns))
+	for _, column := range columns {
+		if !column.Wide {
+			narrow = append(narrow, column)
+		}
+	}
+	return narrow
+}
+
+// filterRows drops the rows not matching --where. Conditions may refer to
+// any of the entity's columns, not only the displayed ones.
+func filterRows(table *output.Table, columns []output.ColumnDefaults) error {
+	if flagWhere == "" {
+		return nil
+	}
+
+	conditions, err := output.ParseWhere(flagWhere)
+	if err != nil {
+		return fmt.Errorf("invalid --where: %w", err)
+	}
+	if err := table.Filter(conditions, columns); err != nil {
+		return fmt.Errorf("invalid --where: %w", err)
+	}
+	return nil
+}
+
+// whereEquals returns the value of an '=' condition of --where on header,
+// which a command can pass on to the server to fetch fewer rows. filterRows
+// still filters the rows and reports an invalid --where.
+func whereEquals(header string) string {
+	conditions, err := output.ParseWhere(flagWhere)
+	if err != nil {
+		return ""
+	}
+	value, _ := output.Equality(conditions, header)
+	return value
+}
+
+// whereIndex returns the index pattern to fetch: --index when set, otherwise
+// the name of an 'INDEX=<name>' condition. The trailing wildcard keeps a
+// missing index from failing the request, filterRows matches the exact name.
+func whereIndex() string {
+	if flagIndex != "" {
+		return flagIndex
+	}
+
+	name := whereEquals("INDEX")
+	if name == "" || strings.ContainsAny(name, ",*?/#%\\ ") || strings.HasPrefix(name, "-") || strings.HasPrefix(name, "_") {
+		return ""
+	}
+	return name + "*"
+}
+
+// whereHealth returns the health of a 'HEALTH=<health>' condition, which
+// _cat/indices can filter on.
+func whereHealth() string {
+	switch health := whereEquals("HEALTH"); health {
+	case "green", "yellow", "red":
+		return health
+	}
+	return ""
+}
+
+// showTable prints the table built by fetch. With --watch it keeps
+// refreshing it, in a full-screen view when running in a terminal and by
+// printing it again every interval otherwise.
+func showTable(cmd *cobra.Command, fetch tui.Fetch, defaultSortBy string) error {
+	sortCols := output.ParseSortColumns(sortBy(defaultSortBy))
+
+	table, err := fetch(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	if !flagRefresh {
+		return table.Print(sortCols)
+	}
+
+	if (output.Format() == output.FormatTable || output.IsWide()) && tui.IsTerminal() {
+		// Reject unknown sort columns before taking over the screen
+		if err := table.Sort(sortCols); err != nil {
+			return err
+		}
+
+		opts := tui.Options{Title: cmd.CommandPath(), Interval: flagRefreshInterval, SortBy: sortBy(defaultSortBy)}
+		return tui.Watch(cmd.Context(), table, fetch, opts)
+	}
+
+	for {
+		if err := table.Print(sortCols); err != nil {
+			return err
+		}
+
+		select {
+		case <-time.After(flagRefreshInterval):
+		case <-cmd.Context().Done():
+			return nil
+		}
+
+		if table, err = fetch(cmd.Context()); err != nil {
+			if cmd.Context().Err() != nil {
+				return nil
+			}
+			return err
+		}
+		fmt.Println()
+	}
+}
+
+// sortBy returns the --sort-by columns, or the entity's default order when none are given.
+func sortBy(defaultColumns string) string {
+	if flagSortBy != "" {
+		return flagSortBy
+	}
+	return defaultColumns
 }
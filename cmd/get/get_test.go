@@ -0,0 +1,32 @@
+package get
+
+import "testing"
+
+func TestWherePushdown(t *testing.T) {
+	defer func(where, index string) { flagWhere, flagIndex = where, index }(flagWhere, flagIndex)
+
+	testCases := []struct {
+		where, index string
+		wantIndex    string
+		wantHealth   string
+	}{
+		{"", "", "", ""},
+		{"INDEX=logs-1,HEALTH=yellow", "", "logs-1*", "yellow"},
+		{"INDEX=logs-1", "metrics", "metrics", ""},
+		{"INDEX=~^logs-,HEALTH!=green", "", "", ""},
+		{"INDEX=logs-*,HEALTH=unknown", "", "", ""},
+		{"INDEX=-logs", "", "", ""},
+		{"INDEX=logs/x", "", "", ""},
+		{"INDEX=~[", "", "", ""},
+	}
+
+	for _, tc := range testCases {
+		flagWhere, flagIndex = tc.where, tc.index
+		if got := whereIndex(); got != tc.wantIndex {
+			t.Errorf("whereIndex() for %q = %q, want %q", tc.where, got, tc.wantIndex)
+		}
+		if got := whereHealth(); got != tc.wantHealth {
+			t.Errorf("whereHealth() for %q = %q, want %q", tc.where, got, tc.wantHealth)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package get
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pincher95/esctl/cmd/config"
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/pincher95/esctl/es"
+	"github.com/pincher95/esctl/output"
+	"github.com/spf13/cobra"
+)
+
+var getLifecyclePoliciesCmd = &cobra.Command{
+	Use:     "ilm-policies",
+	Aliases: []string{"ism-policies"},
+	Short:   "Get index lifecycle policies",
+	Long: utils.Trim(`
+	Get the ILM policies with their phases and the number of indices using them.
+	On OpenSearch the ISM policies are listed instead, with their states as phases.
+	`),
+	Example: utils.TrimAndIndent(`
+	# Retrieve all lifecycle policies.
+	esctl get ilm-policies
+
+	# Retrieve the policies not used by any index.
+	esctl get ilm-policies --where 'INDICES=0'
+	`),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := config.ParseConfigFile()
+		if err != nil {
+			return err
+		}
+
+		return showTable(cmd, func(ctx context.Context) (*output.Table, error) {
+			return lifecyclePolicyTable(ctx, *config)
+		}, "NAME")
+	},
+}
+
+var lifecyclePolicyColumns = []output.ColumnDefaults{
+	{Header: "NAME", Type: output.Text},
+	{Header: "VERSION", Type: output.Number},
+	{Header: "MODIFIED", Type: output.Date},
+	{Header: "PHASES", Type: output.Text},
+	{Header: "INDICES", Type: output.Number},
+	{Header: "DATA-STREAMS", Type: output.Number, Wide: true},
+	{Header: "DESCRIPTION", Type: output.Text, Wide: true},
+}
+
+func lifecyclePolicyTable(ctx context.Context, conf config.Config) (*output.Table, error) {
+	policies, err := es.GetLifecyclePolicies(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to retrieve lifecycle policies: %w", err)
+	}
+
+	columnDefs, err := getColumnDefs(conf, "ilm-policy", lifecyclePolicyColumns)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get column definitions: %w", err)
+	}
+
+	table := output.NewTable(columnDefs, "NAME")
+
+	for _, policy := range policies {
+		phases := make([]string, 0, len(policy.Phases))
+		for _, phase := range policy.Phases {
+			phases = append(phases, phase.Name)
+		}
+
+		rowData := map[string]string{
+			"NAME":         policy.Name,
+			"VERSION":      strconv.Itoa(policy.Version),
+			"MODIFIED":     policy.Modified,
+			"PHASES":       strings.Join(phases, ","),
+			"INDICES":      countOrEmpty(policy.Indices),
+			"DATA-STREAMS": countOrEmpty(policy.DataStreams),
+			"DESCRIPTION":  policy.Description,
+		}
+
+		table.AddRow(policy, rowData)
+	}
+
+	return table, filterRows(table, lifecyclePolicyColumns)
+}
+
+// countOrEmpty counts the items of a list, or is empty when the cluster does
+// not report the list, e.g. the indices using an ISM policy.
+func countOrEmpty(items []string) string {
+	if items == nil {
+		return ""
+	}
+	return strconv.Itoa(len(items))
+}
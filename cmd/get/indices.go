@@ -1,10 +1,9 @@
 package get
 
 import (
+	"context"
 	"fmt"
-	"os"
 	"strconv"
-	"time"
 
 	"github.com/pincher95/esctl/cmd/config"
 	"github.com/pincher95/esctl/cmd/utils"
@@ -26,21 +25,15 @@ var getIndicesCmd = &cobra.Command{
 	# Retrieve indices for a specific index.
 	esctl get indices --index my_index
 	`),
-	Run: func(cmd *cobra.Command, args []string) {
-		config := config.ParseConfigFile()
-
-		// If --watch is NOT set, just run once
-		if !flagRefresh {
-			handleIndicesLogic(*config)
-			return
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := config.ParseConfigFile()
+		if err != nil {
+			return err
 		}
 
-		// If --watch is set, run in a loop
-		for {
-			clearScreen() // optional, to mimic "watch" clearing
-			handleIndicesLogic(*config)
-			time.Sleep(flagRefreshInterval)
-		}
+		return showTable(cmd, func(ctx context.Context) (*output.Table, error) {
+			return indicesTable(ctx, *config)
+		}, "INDEX")
 	},
 }
 
@@ -62,20 +55,19 @@ var indexColumns = []output.ColumnDefaults{
 	{Header: "PRI-STORE-SIZE", Type: output.DataSize},
 }
 
-func handleIndicesLogic(conf config.Config) {
-	indices, err := cat.CatIndices(nil, &flagIndex, &flagBytes)
+func indicesTable(ctx context.Context, conf config.Config) (*output.Table, error) {
+	endpoint := cat.IndicesEndpoint(whereIndex(), whereHealth())
+	indices, err := cat.CatIndices(ctx, &endpoint, nil, &flagBytes)
 	if err != nil {
-		fmt.Fprintln(os.Stderr, "Failed to retrieve indices:", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("Failed to retrieve indices: %w", err)
 	}
 
 	columnDefs, err := getColumnDefs(conf, "index", indexColumns)
 	if err != nil {
-		fmt.Fprintln(os.Stderr, "Failed to get column definitions:", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("Failed to get column definitions: %w", err)
 	}
 
-	data := [][]string{}
+	table := output.NewTable(columnDefs, "INDEX")
 
 	for _, index := range indices {
 		rowData := map[string]string{
@@ -91,18 +83,8 @@ func handleIndicesLogic(conf config.Config) {
 			"PRI-STORE-SIZE": utils.SafeString(index.PrimaryStoreSize),
 		}
 
-		row := make([]string, len(columnDefs))
-		for i, colDef := range columnDefs {
-			row[i] = rowData[colDef.Header]
-		}
-		data = append(data, row)
+		table.AddRow(index, rowData)
 	}
 
-	if len(flagSortBy) > 0 {
-		sortCols := output.ParseSortColumns(flagSortBy)
-		output.PrintTable(columnDefs, data, sortCols)
-	} else {
-		sortCols := output.ParseSortColumns("INDEX")
-		output.PrintTable(columnDefs, data, sortCols)
-	}
+	return table, filterRows(table, indexColumns)
 }
@@ -1,12 +1,12 @@
 package get
 
 import (
+	"context"
 	"fmt"
-	"os"
-	"time"
 
 	"github.com/pincher95/esctl/cmd/config"
 	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/pincher95/esctl/es/distribution"
 	"github.com/pincher95/esctl/output"
 	"github.com/spf13/cobra"
 
@@ -17,22 +17,14 @@ var getNodesCmd = &cobra.Command{
 	Use:   "nodes",
 	Short: "Get all nodes in the Elasticsearch cluster",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// ctx := cmd.Context()
-		config := config.ParseConfigFile()
-
-		// select
-		// If --watch is NOT set, just run once
-		if !flagRefresh {
-			handleNodeLogic(*config)
-			return nil
+		config, err := config.ParseConfigFile()
+		if err != nil {
+			return err
 		}
 
-		// If --watch is set, run in a loop
-		for {
-			clearScreen() // optional, to mimic "watch" clearing
-			handleNodeLogic(*config)
-			time.Sleep(flagRefreshInterval)
-		}
+		return showTable(cmd, func(ctx context.Context) (*output.Table, error) {
+			return nodeTable(ctx, *config)
+		}, "NAME")
 	},
 }
 
@@ -56,20 +48,37 @@ var nodeColumns = []output.ColumnDefaults{
 	{Header: "NAME", Type: output.Text},
 }
 
-func handleNodeLogic(conf config.Config) {
-	nodes, err := cat.CatNodes(nil, &flagNode, &flagBytes, &flagTime)
+// nodeColumnsFor adapts the node columns to the distribution: NODE-ROLES
+// only exists on OpenSearch, which calls the master CLUSTER-MANAGER since 2.0.
+func nodeColumnsFor(info distribution.Info) []output.ColumnDefaults {
+	columns := make([]output.ColumnDefaults, 0, len(nodeColumns))
+	for _, column := range nodeColumns {
+		switch {
+		case column.Header == "NODE-ROLES" && !info.IsOpenSearch():
+			continue
+		case column.Header == "MASTER" && info.UsesClusterManager():
+			column.Header = "CLUSTER-MANAGER"
+		}
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+func nodeTable(ctx context.Context, conf config.Config) (*output.Table, error) {
+	nodes, err := cat.CatNodes(ctx, nil, &flagNode, &flagBytes, &flagTime)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to retrieve nodes: %v\n", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("Failed to retrieve nodes: %w", err)
 	}
 
-	columnDefs, err := getColumnDefs(conf, "node", nodeColumns)
+	info := distribution.Detect(ctx)
+	columns := nodeColumnsFor(info)
+
+	columnDefs, err := getColumnDefs(conf, "node", columns)
 	if err != nil {
-		fmt.Fprintln(os.Stderr, "Failed to get column definitions:", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("Failed to get column definitions: %w", err)
 	}
 
-	data := [][]string{}
+	table := output.NewTable(columnDefs, "NAME")
 
 	for _, node := range nodes {
 		rowData := map[string]string{
@@ -85,19 +94,13 @@ func handleNodeLogic(conf config.Config) {
 			"MASTER":       node.Master,
 			"NAME":         node.Name,
 		}
-
-		row := make([]string, len(columnDefs))
-		for i, colDef := range columnDefs {
-			row[i] = rowData[colDef.Header]
+		if info.UsesClusterManager() {
+			delete(rowData, "MASTER")
+			rowData["CLUSTER-MANAGER"] = node.ClusterManager
 		}
-		data = append(data, row)
-	}
 
-	if len(flagSortBy) > 0 {
-		sortCols := output.ParseSortColumns(flagSortBy)
-		output.PrintTable(columnDefs, data, sortCols)
-	} else {
-		sortCols := output.ParseSortColumns("NAME")
-		output.PrintTable(columnDefs, data, sortCols)
+		table.AddRow(node, rowData)
 	}
+
+	return table, filterRows(table, columns)
 }
@@ -1,9 +1,8 @@
 package get
 
 import (
+	"context"
 	"fmt"
-	"os"
-	"time"
 
 	"github.com/pincher95/esctl/cmd/config"
 	"github.com/pincher95/esctl/cmd/utils"
@@ -22,21 +21,15 @@ var getPluginsCmd = &cobra.Command{
 	# Retrieve all plugins.
 	esctl get plugins
 	`),
-	Run: func(cmd *cobra.Command, args []string) {
-		config := config.ParseConfigFile()
-
-		// If --watch is NOT set, just run once
-		if !flagRefresh {
-			handlePluginsLogic(*config)
-			return
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := config.ParseConfigFile()
+		if err != nil {
+			return err
 		}
 
-		// If --watch is set, run in a loop
-		for {
-			clearScreen() // optional, to mimic "watch" clearing
-			handlePluginsLogic(*config)
-			time.Sleep(flagRefreshInterval)
-		}
+		return showTable(cmd, func(ctx context.Context) (*output.Table, error) {
+			return pluginsTable(ctx, *config)
+		}, "NAME")
 	},
 }
 
@@ -51,20 +44,18 @@ var pluginsColumns = []output.ColumnDefaults{
 	{Header: "DESCRIPTION", Type: output.Text},
 }
 
-func handlePluginsLogic(conf config.Config) {
-	plugins, err := cat.CatPlugins(nil)
+func pluginsTable(ctx context.Context, conf config.Config) (*output.Table, error) {
+	plugins, err := cat.CatPlugins(ctx, nil)
 	if err != nil {
-		fmt.Fprintln(os.Stderr, "Failed to retrieve plugins:", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("Failed to retrieve plugins: %w", err)
 	}
 
 	columnDefs, err := getColumnDefs(conf, "plugins", pluginsColumns)
 	if err != nil {
-		fmt.Fprintln(os.Stderr, "Failed to get column definitions:", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("Failed to get column definitions: %w", err)
 	}
 
-	data := [][]string{}
+	table := output.NewTable(columnDefs, "NAME", "COMPONENT")
 
 	for _, plugin := range plugins {
 		rowData := map[string]string{
@@ -74,18 +65,8 @@ func handlePluginsLogic(conf config.Config) {
 			"DESCRIPTION": plugin.Description,
 		}
 
-		row := make([]string, len(columnDefs))
-		for i, colDef := range columnDefs {
-			row[i] = rowData[colDef.Header]
-		}
-		data = append(data, row)
+		table.AddRow(plugin, rowData)
 	}
 
-	if len(flagSortBy) > 0 {
-		sortCols := output.ParseSortColumns(flagSortBy)
-		output.PrintTable(columnDefs, data, sortCols)
-	} else {
-		sortCols := output.ParseSortColumns("NAME")
-		output.PrintTable(columnDefs, data, sortCols)
-	}
+	return table, filterRows(table, pluginsColumns)
 }
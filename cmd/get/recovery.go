@@ -0,0 +1,201 @@
+package get
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pincher95/esctl/cmd/config"
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/pincher95/esctl/es"
+	"github.com/pincher95/esctl/output"
+	"github.com/spf13/cobra"
+)
+
+var getRecoveryCmd = &cobra.Command{
+	Use:     "recovery",
+	Aliases: []string{"recoveries"},
+	Short:   "Get shard recoveries and their progress",
+	Long: utils.Trim(`
+	Get the recoveries of shards, e.g. after a node restarted or while shards relocate, with their source and target node,
+	stage, progress in bytes and files, throughput and estimated time left.
+	Without '--watch' the throughput is the average since the recovery started, with '--watch' it is measured between refreshes.
+	`),
+	Example: utils.TrimAndIndent(`
+	# Follow the recoveries in progress.
+	esctl get recovery --active-only --watch
+
+	# Retrieve the recoveries of an index, including completed ones.
+	esctl get recovery --index my_index
+	`),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := config.ParseConfigFile()
+		if err != nil {
+			return err
+		}
+
+		rates := newRecoveryRates()
+		return showTable(cmd, func(ctx context.Context) (*output.Table, error) {
+			return recoveryTable(ctx, *config, rates)
+		}, "INDEX,SHARD")
+	},
+}
+
+func init() {
+	getRecoveryCmd.Flags().StringVarP(&flagIndex, "index", "i", "", "Name of the index")
+	getRecoveryCmd.Flags().BoolVar(&flagActiveOnly, "active-only", false, "Only show recoveries in progress")
+}
+
+var recoveryColumns = []output.ColumnDefaults{
+	{Header: "INDEX", Type: output.Text},
+	{Header: "SHARD", Type: output.Number},
+	{Header: "PRI-REP", Type: output.Text, Wide: true},
+	{Header: "TYPE", Type: output.Text},
+	{Header: "STAGE", Type: output.Text},
+	{Header: "SOURCE", Type: output.Text},
+	{Header: "TARGET", Type: output.Text},
+	{Header: "BYTES-PERCENT", Type: output.Percent},
+	{Header: "BYTES", Type: output.DataSize, Wide: true},
+	{Header: "BYTES-TOTAL", Type: output.DataSize},
+	{Header: "FILES-PERCENT", Type: output.Percent},
+	{Header: "FILES", Type: output.Number, Wide: true},
+	{Header: "FILES-TOTAL", Type: output.Number, Wide: true},
+	{Header: "TRANSLOG-PERCENT", Type: output.Percent, Wide: true},
+	{Header: "RATE", Type: output.DataSize},
+	{Header: "ETA", Type: output.Text},
+	{Header: "TIME", Type: output.Text, Wide: true},
+}
+
+func recoveryTable(ctx context.Context, conf config.Config, rates *recoveryRates) (*output.Table, error) {
+	recoveries, err := es.GetRecoveries(ctx, flagIndex, flagActiveOnly)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to retrieve recoveries: %w", err)
+	}
+	now := time.Now()
+
+	columnDefs, err := getColumnDefs(conf, "recovery", recoveryColumns)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get column definitions: %w", err)
+	}
+
+	table := output.NewTable(columnDefs, "INDEX", "SHARD", "TARGET")
+
+	rates.update(recoveries, now)
+	for _, recovery := range recoveries {
+		priRep := "replica"
+		if recovery.Primary {
+			priRep = "primary"
+		}
+
+		rateData, etaData := "", ""
+		if rate, eta, ok := rates.get(recovery); ok {
+			rateData = utils.FormatBytes(int64(rate)) + "/s"
+			if eta > 0 {
+				etaData = eta.String()
+			}
+		}
+
+		files := recovery.Files
+		rowData := map[string]string{
+			"INDEX":            recovery.Index,
+			"SHARD":            strconv.Itoa(recovery.ID),
+			"PRI-REP":          priRep,
+			"TYPE":             recovery.Type,
+			"STAGE":            recovery.Stage,
+			"SOURCE":           recoverySource(recovery),
+			"TARGET":           recovery.Target.Name,
+			"BYTES-PERCENT":    percent(files.RecoveredBytes, files.TotalBytes-files.ReusedBytes),
+			"BYTES":            utils.FormatBytes(files.RecoveredBytes),
+			"BYTES-TOTAL":      utils.FormatBytes(files.TotalBytes),
+			"FILES-PERCENT":    percent(int64(files.Recovered), int64(files.Total-files.Reused)),
+			"FILES":            strconv.Itoa(files.Recovered),
+			"FILES-TOTAL":      strconv.Itoa(files.Total),
+			"TRANSLOG-PERCENT": percent(int64(recovery.Translog.Recovered), int64(recovery.Translog.Total)),
+			"RATE":             rateData,
+			"ETA":              etaData,
+			"TIME":             (time.Duration(recovery.TotalTimeInMillis) * time.Millisecond).Truncate(time.Second).String(),
+		}
+
+		table.AddRow(recovery, rowData)
+	}
+
+	return table, filterRows(table, recoveryColumns)
+}
+
+// recoverySource is the node a shard is copied from, or the snapshot it is
+// restored from. Shards recovered from their own disk have no source.
+func recoverySource(recovery es.ShardRecovery) string {
+	switch {
+	case recovery.Type == es.RecoveryTypeSnapshot:
+		return recovery.Source.Repository + "/" + recovery.Source.Snapshot
+	case recovery.Source.Name != "":
+		return recovery.Source.Name
+	default:
+		return ""
+	}
+}
+
+type recoverySample struct {
+	bytes int64
+	at    time.Time
+}
+
+// recoveryRates measures the throughput of every recovery between two
+// consecutive samples, falling back to the average since the recovery
+// started when there is no earlier sample or the recovery is done.
+type recoveryRates struct {
+	previous map[string]recoverySample
+	rates    map[string]float64
+}
+
+func newRecoveryRates() *recoveryRates {
+	return &recoveryRates{previous: make(map[string]recoverySample), rates: make(map[string]float64)}
+}
+
+func recoveryKey(recovery es.ShardRecovery) string {
+	return fmt.Sprintf("%s/%d/%s/%s", recovery.Index, recovery.ID, recovery.Target.ID, recovery.Type)
+}
+
+// update records a sample of every recovery taken at now. Recoveries missing
+// from the sample are forgotten.
+func (r *recoveryRates) update(recoveries []es.ShardRecovery, now time.Time) {
+	current := make(map[string]recoverySample, len(recoveries))
+	r.rates = make(map[string]float64, len(recoveries))
+
+	for _, recovery := range recoveries {
+		key := recoveryKey(recovery)
+		sample := recoverySample{bytes: recovery.Files.RecoveredBytes, at: now}
+		current[key] = sample
+
+		previous, ok := r.previous[key]
+		elapsed := sample.at.Sub(previous.at).Seconds()
+		switch {
+		case ok && recovery.Stage != "DONE" && elapsed > 0 && sample.bytes >= previous.bytes:
+			r.rates[key] = float64(sample.bytes-previous.bytes) / elapsed
+		case recovery.TotalTimeInMillis > 0:
+			r.rates[key] = float64(sample.bytes) / (float64(recovery.TotalTimeInMillis) / 1000)
+		}
+	}
+	r.previous = current
+}
+
+// get returns the throughput of a recovery in bytes per second and the time
+// left at that rate, which is zero once all bytes are recovered.
+func (r *recoveryRates) get(recovery es.ShardRecovery) (float64, time.Duration, bool) {
+	rate, ok := r.rates[recoveryKey(recovery)]
+	if !ok {
+		return 0, 0, false
+	}
+
+	files := recovery.Files
+	remaining := files.TotalBytes - files.ReusedBytes - files.RecoveredBytes
+	if recovery.Stage == "DONE" || remaining <= 0 || rate <= 0 {
+		return rate, 0, true
+	}
+	eta := time.Duration(float64(remaining) / rate * float64(time.Second)).Truncate(time.Second)
+	if eta < time.Second {
+		eta = time.Second
+	}
+	return rate, eta, true
+}
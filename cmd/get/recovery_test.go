@@ -0,0 +1,48 @@
+package get
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pincher95/esctl/es"
+)
+
+func TestRecoveryRates(t *testing.T) {
+	recovery := func(stage string, recovered int64, totalTime int64) es.ShardRecovery {
+		return es.ShardRecovery{
+			Index: "logs", ID: 0, Type: es.RecoveryTypePeer, Stage: stage,
+			TotalTimeInMillis: totalTime,
+			Target:            es.RecoveryNode{ID: "n2"},
+			Files:             es.RecoveryFiles{TotalBytes: 1000, ReusedBytes: 100, RecoveredBytes: recovered},
+		}
+	}
+	start := time.Now()
+	rates := newRecoveryRates()
+
+	// The first sample only has the average since the recovery started
+	first := recovery("INDEX", 100, 10000)
+	rates.update([]es.ShardRecovery{first}, start)
+	if rate, eta, ok := rates.get(first); !ok || rate != 10 || eta != 80*time.Second {
+		t.Errorf("first sample: got %v, %v, %v, want 10, 1m20s, true", rate, eta, ok)
+	}
+
+	// Later samples measure the bytes recovered in between
+	second := recovery("INDEX", 500, 12000)
+	rates.update([]es.ShardRecovery{second}, start.Add(2*time.Second))
+	if rate, eta, ok := rates.get(second); !ok || rate != 200 || eta != 2*time.Second {
+		t.Errorf("second sample: got %v, %v, %v, want 200, 2s, true", rate, eta, ok)
+	}
+
+	// Done recoveries report their average and no time left
+	done := recovery("DONE", 900, 15000)
+	rates.update([]es.ShardRecovery{done}, start.Add(4*time.Second))
+	if rate, eta, ok := rates.get(done); !ok || rate != 60 || eta != 0 {
+		t.Errorf("done: got %v, %v, %v, want 60, 0s, true", rate, eta, ok)
+	}
+
+	// Recoveries without a rate yet are left empty
+	rates.update(nil, start.Add(6*time.Second))
+	if _, _, ok := rates.get(done); ok {
+		t.Error("expected no rate for a recovery missing from the last sample")
+	}
+}
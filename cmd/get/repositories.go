@@ -0,0 +1,67 @@
+package get
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pincher95/esctl/cmd/config"
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/pincher95/esctl/es"
+	"github.com/pincher95/esctl/output"
+	"github.com/spf13/cobra"
+)
+
+var getRepositoriesCmd = &cobra.Command{
+	Use:     "repositories",
+	Aliases: []string{"repos"},
+	Short:   "Get snapshot repositories",
+	Long: utils.Trim(`
+	Get the snapshot repositories registered in the cluster, with their type and location.
+	`),
+	Example: utils.TrimAndIndent(`
+	# Retrieve all snapshot repositories.
+	esctl get repositories
+	`),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := config.ParseConfigFile()
+		if err != nil {
+			return err
+		}
+
+		return showTable(cmd, func(ctx context.Context) (*output.Table, error) {
+			return repositoryTable(ctx, *config)
+		}, "NAME")
+	},
+}
+
+var repositoryColumns = []output.ColumnDefaults{
+	{Header: "NAME", Type: output.Text},
+	{Header: "TYPE", Type: output.Text},
+	{Header: "LOCATION", Type: output.Text},
+}
+
+func repositoryTable(ctx context.Context, conf config.Config) (*output.Table, error) {
+	repositories, err := es.GetRepositories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to retrieve repositories: %w", err)
+	}
+
+	columnDefs, err := getColumnDefs(conf, "repository", repositoryColumns)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get column definitions: %w", err)
+	}
+
+	table := output.NewTable(columnDefs, "NAME")
+
+	for _, repository := range repositories {
+		rowData := map[string]string{
+			"NAME":     repository.Name,
+			"TYPE":     repository.Type,
+			"LOCATION": repository.Location(),
+		}
+
+		table.AddRow(repository, rowData)
+	}
+
+	return table, filterRows(table, repositoryColumns)
+}
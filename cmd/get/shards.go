@@ -1,10 +1,9 @@
 package get
 
 import (
+	"context"
 	"fmt"
-	"os"
 	"strconv"
-	"time"
 
 	"github.com/pincher95/esctl/cmd/config"
 	"github.com/pincher95/esctl/cmd/utils"
@@ -37,21 +36,15 @@ esctl get shards --index my_index
 
 # Retrieve shard information filtered by state.
 esctl get shards --started --relocating`),
-	Run: func(cmd *cobra.Command, args []string) {
-		config := config.ParseConfigFile()
-
-		// If --watch is NOT set, just run once
-		if !flagRefresh {
-			handleShardLogic(*config)
-			return
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := config.ParseConfigFile()
+		if err != nil {
+			return err
 		}
 
-		// If --watch is set, run in a loop
-		for {
-			clearScreen() // optional, to mimic "watch" clearing
-			handleShardLogic(*config)
-			time.Sleep(flagRefreshInterval)
-		}
+		return showTable(cmd, func(ctx context.Context) (*output.Table, error) {
+			return shardTable(ctx, *config)
+		}, "SHARD")
 	},
 }
 
@@ -123,20 +116,19 @@ var shardColumns = []output.ColumnDefaults{
 	{Header: "NODE", Type: output.Text},
 }
 
-func handleShardLogic(conf config.Config) {
-	shards, err := cat.CatShards(nil, &flagIndex, nil, nil)
+func shardTable(ctx context.Context, conf config.Config) (*output.Table, error) {
+	index := whereIndex()
+	shards, err := cat.CatShards(ctx, nil, &index, nil, nil)
 	if err != nil {
-		fmt.Fprintln(os.Stderr, "Failed to retrieve shards:", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("Failed to retrieve shards: %w", err)
 	}
 
 	columnDefs, err := getColumnDefs(conf, "shard", shardColumns)
 	if err != nil {
-		fmt.Fprintln(os.Stderr, "Failed to get column definitions:", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("Failed to get column definitions: %w", err)
 	}
 
-	data := [][]string{}
+	table := output.NewTable(columnDefs, "INDEX", "SHARD", "PRI-REP")
 
 	for _, shard := range shards {
 		if includeShardByState(shard) && includeShardByNumber(shard) &&
@@ -153,19 +145,9 @@ func handleShardLogic(conf config.Config) {
 				"NODE":    utils.SafeString(shard.Node),
 			}
 
-			row := make([]string, len(columnDefs))
-			for i, colDef := range columnDefs {
-				row[i] = rowData[colDef.Header]
-			}
-			data = append(data, row)
+			table.AddRow(shard, rowData)
 		}
 	}
 
-	if len(flagSortBy) > 0 {
-		sortCols := output.ParseSortColumns(flagSortBy)
-		output.PrintTable(columnDefs, data, sortCols)
-	} else {
-		sortCols := output.ParseSortColumns("SHARD")
-		output.PrintTable(columnDefs, data, sortCols)
-	}
+	return table, filterRows(table, shardColumns)
 }
@@ -0,0 +1,103 @@
+package get
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/pincher95/esctl/cmd/config"
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/pincher95/esctl/es"
+	"github.com/pincher95/esctl/output"
+	"github.com/spf13/cobra"
+)
+
+var getSnapshotProgressCmd = &cobra.Command{
+	Use:   "snapshot-progress",
+	Short: "Get the progress of running snapshots and restores per shard",
+	Long: utils.Trim(`
+	Get the progress of every shard of the running snapshots and of the indices being restored from a snapshot.
+	Combine it with '--watch' to follow a snapshot or restore until it is done.
+	`),
+	Example: utils.TrimAndIndent(`
+	# Follow the running snapshots and restores.
+	esctl get snapshot-progress --watch
+
+	# Follow the shards of one repository that are not done yet.
+	esctl get snapshot-progress --repo my_repo --where 'STAGE!=DONE' --watch
+	`),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := config.ParseConfigFile()
+		if err != nil {
+			return err
+		}
+
+		return showTable(cmd, func(ctx context.Context) (*output.Table, error) {
+			return snapshotProgressTable(ctx, *config)
+		}, "SNAPSHOT,INDEX,SHARD")
+	},
+}
+
+func init() {
+	getSnapshotProgressCmd.Flags().StringVarP(&flagRepository, "repo", "r", "", "Only show snapshots of this repository")
+}
+
+var snapshotProgressColumns = []output.ColumnDefaults{
+	{Header: "OPERATION", Type: output.Text},
+	{Header: "REPOSITORY", Type: output.Text, Wide: true},
+	{Header: "SNAPSHOT", Type: output.Text},
+	{Header: "INDEX", Type: output.Text},
+	{Header: "SHARD", Type: output.Number},
+	{Header: "STAGE", Type: output.Text},
+	{Header: "NODE", Type: output.Text},
+	{Header: "BYTES-PERCENT", Type: output.Percent},
+	{Header: "BYTES", Type: output.DataSize, Wide: true},
+	{Header: "BYTES-TOTAL", Type: output.DataSize},
+	{Header: "FILES-PERCENT", Type: output.Percent, Wide: true},
+	{Header: "FILES", Type: output.Number, Wide: true},
+	{Header: "FILES-TOTAL", Type: output.Number, Wide: true},
+}
+
+func snapshotProgressTable(ctx context.Context, conf config.Config) (*output.Table, error) {
+	progress, err := es.GetSnapshotProgress(ctx, flagRepository)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to retrieve snapshot progress: %w", err)
+	}
+
+	columnDefs, err := getColumnDefs(conf, "snapshot-progress", snapshotProgressColumns)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get column definitions: %w", err)
+	}
+
+	table := output.NewTable(columnDefs, "SNAPSHOT", "INDEX", "SHARD")
+
+	for _, shard := range progress {
+		rowData := map[string]string{
+			"OPERATION":     shard.Operation,
+			"REPOSITORY":    shard.Repository,
+			"SNAPSHOT":      shard.Snapshot,
+			"INDEX":         shard.Index,
+			"SHARD":         strconv.Itoa(shard.Shard),
+			"STAGE":         shard.Stage,
+			"NODE":          shard.Node,
+			"BYTES-PERCENT": percent(shard.BytesDone, shard.BytesTotal),
+			"BYTES":         utils.FormatBytes(shard.BytesDone),
+			"BYTES-TOTAL":   utils.FormatBytes(shard.BytesTotal),
+			"FILES-PERCENT": percent(int64(shard.FilesDone), int64(shard.FilesTotal)),
+			"FILES":         strconv.Itoa(shard.FilesDone),
+			"FILES-TOTAL":   strconv.Itoa(shard.FilesTotal),
+		}
+
+		table.AddRow(shard, rowData)
+	}
+
+	return table, filterRows(table, snapshotProgressColumns)
+}
+
+// percent formats done of total as a percentage, where nothing to do is done.
+func percent(done, total int64) string {
+	if total == 0 {
+		return "100.0%"
+	}
+	return fmt.Sprintf("%.1f%%", float64(done)*100/float64(total))
+}
@@ -0,0 +1,96 @@
+package get
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pincher95/esctl/cmd/config"
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/pincher95/esctl/es"
+	"github.com/pincher95/esctl/output"
+	"github.com/spf13/cobra"
+)
+
+var getSnapshotsCmd = &cobra.Command{
+	Use:   "snapshots",
+	Short: "Get the snapshots of a repository",
+	Long: utils.Trim(`
+	Get the snapshots of a repository with their state, timing and shard counts.
+	Use '--snapshot' to only list snapshots matching a pattern.
+	`),
+	Example: utils.TrimAndIndent(`
+	# Retrieve all snapshots of a repository.
+	esctl get snapshots --repo my_repo
+
+	# Retrieve the nightly snapshots that did not succeed.
+	esctl get snapshots --repo my_repo --snapshot 'nightly-*' --where 'STATE!=SUCCESS'
+	`),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := config.ParseConfigFile()
+		if err != nil {
+			return err
+		}
+
+		return showTable(cmd, func(ctx context.Context) (*output.Table, error) {
+			return snapshotTable(ctx, *config)
+		}, "START-TIME")
+	},
+}
+
+func init() {
+	getSnapshotsCmd.Flags().StringVarP(&flagRepository, "repo", "r", "", "Name of the snapshot repository")
+	getSnapshotsCmd.Flags().StringVar(&flagSnapshot, "snapshot", "", "Name or pattern of the snapshots")
+	_ = getSnapshotsCmd.MarkFlagRequired("repo")
+}
+
+var snapshotColumns = []output.ColumnDefaults{
+	{Header: "SNAPSHOT", Type: output.Text},
+	{Header: "REPOSITORY", Type: output.Text, Wide: true},
+	{Header: "UUID", Type: output.Text, Wide: true},
+	{Header: "STATE", Type: output.Text},
+	{Header: "START-TIME", Type: output.Date},
+	{Header: "END-TIME", Type: output.Date, Wide: true},
+	{Header: "DURATION", Type: output.Text},
+	{Header: "INDICES", Type: output.Number},
+	{Header: "SHARDS", Type: output.Number},
+	{Header: "FAILED-SHARDS", Type: output.Number},
+	{Header: "GLOBAL-STATE", Type: output.Boolean, Wide: true},
+	{Header: "VERSION", Type: output.Text, Wide: true},
+}
+
+func snapshotTable(ctx context.Context, conf config.Config) (*output.Table, error) {
+	snapshots, err := es.GetSnapshots(ctx, flagRepository, flagSnapshot)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to retrieve snapshots: %w", err)
+	}
+
+	columnDefs, err := getColumnDefs(conf, "snapshot", snapshotColumns)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get column definitions: %w", err)
+	}
+
+	table := output.NewTable(columnDefs, "SNAPSHOT")
+
+	for _, snapshot := range snapshots {
+		rowData := map[string]string{
+			"SNAPSHOT":      snapshot.Snapshot,
+			"REPOSITORY":    snapshot.Repository,
+			"UUID":          snapshot.UUID,
+			"STATE":         snapshot.State,
+			"START-TIME":    snapshot.StartTime,
+			"END-TIME":      snapshot.EndTime,
+			"DURATION":      (time.Duration(snapshot.DurationInMillis) * time.Millisecond).Truncate(time.Second).String(),
+			"INDICES":       strconv.Itoa(len(snapshot.Indices)),
+			"SHARDS":        strconv.Itoa(snapshot.Shards.Total),
+			"FAILED-SHARDS": strconv.Itoa(snapshot.Shards.Failed),
+			"GLOBAL-STATE":  strconv.FormatBool(snapshot.IncludeGlobalState),
+			"VERSION":       snapshot.Version,
+		}
+
+		table.AddRow(snapshot, rowData)
+	}
+
+	return table, filterRows(table, snapshotColumns)
+}
@@ -1,9 +1,8 @@
 package get
 
 import (
+	"context"
 	"fmt"
-	"os"
-	"time"
 
 	"github.com/pincher95/esctl/cmd/config"
 	"github.com/pincher95/esctl/es"
@@ -15,21 +14,15 @@ var getTasksCmd = &cobra.Command{
 	Use:   "tasks",
 	Short: "Get tasks information",
 	Long:  `This command retrieves and displays tasks information from Elasticsearch cluster.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		config := config.ParseConfigFile()
-
-		// If --watch is NOT set, just run once
-		if !flagRefresh {
-			handleTaskLogic(*config)
-			return
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := config.ParseConfigFile()
+		if err != nil {
+			return err
 		}
 
-		// If --watch is set, run in a loop
-		for {
-			clearScreen() // optional, to mimic "watch" clearing
-			handleTaskLogic(*config)
-			time.Sleep(flagRefreshInterval)
-		}
+		return showTable(cmd, func(ctx context.Context) (*output.Table, error) {
+			return taskTable(ctx, *config)
+		}, "NODE,ID")
 	},
 }
 
@@ -46,20 +39,18 @@ var taskColumns = []output.ColumnDefaults{
 	{Header: "RUNNING-TIME", Type: output.Number},
 }
 
-func handleTaskLogic(config config.Config) {
-	tasksResponse, err := es.GetTasks(flagActions)
+func taskTable(ctx context.Context, config config.Config) (*output.Table, error) {
+	tasksResponse, err := es.GetTasks(ctx, flagActions)
 	if err != nil {
-		fmt.Fprintln(os.Stderr, "Failed to retrieve tasks:", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("Failed to retrieve tasks: %w", err)
 	}
 
 	columnDefs, err := getColumnDefs(config, "task", taskColumns)
 	if err != nil {
-		fmt.Fprintln(os.Stderr, "Failed to get column definitions:", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("Failed to get column definitions: %w", err)
 	}
 
-	data := [][]string{}
+	table := output.NewTable(columnDefs, "NODE", "ID")
 
 	for _, node := range tasksResponse.Nodes {
 		for _, task := range node.Tasks {
@@ -72,19 +63,9 @@ func handleTaskLogic(config config.Config) {
 				"RUNNING-TIME": fmt.Sprintf("%d", task.RunningTimeInNanos),
 			}
 
-			row := make([]string, len(columnDefs))
-			for i, colDef := range columnDefs {
-				row[i] = rowData[colDef.Header]
-			}
-			data = append(data, row)
+			table.AddRow(task, rowData)
 		}
 	}
 
-	if len(flagSortBy) > 0 {
-		sortCols := output.ParseSortColumns(flagSortBy)
-		output.PrintTable(columnDefs, data, sortCols)
-	} else {
-		sortCols := output.ParseSortColumns("NODE,ID")
-		output.PrintTable(columnDefs, data, sortCols)
-	}
+	return table, filterRows(table, taskColumns)
 }
@@ -0,0 +1,159 @@
+package get
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pincher95/esctl/cmd/config"
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/pincher95/esctl/es"
+	"github.com/pincher95/esctl/output"
+	"github.com/spf13/cobra"
+)
+
+var getTemplatesCmd = &cobra.Command{
+	Use:     "templates",
+	Aliases: []string{"index-templates"},
+	Short:   "Get composable index templates",
+	Long: utils.Trim(`
+	Get the composable index templates with their index patterns, priority and the component templates they are composed of.
+	When several templates match a new index, the one with the highest priority is applied.
+	`),
+	Example: utils.TrimAndIndent(`
+	# Retrieve all index templates, highest priority first.
+	esctl get templates --sort-by PRIORITY:desc
+
+	# Retrieve the templates whose name starts with logs.
+	esctl get templates --template 'logs*'
+	`),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := config.ParseConfigFile()
+		if err != nil {
+			return err
+		}
+
+		return showTable(cmd, func(ctx context.Context) (*output.Table, error) {
+			return templateTable(ctx, *config)
+		}, "NAME")
+	},
+}
+
+var getComponentTemplatesCmd = &cobra.Command{
+	Use:   "component-templates",
+	Short: "Get component templates",
+	Long: utils.Trim(`
+	Get the component templates, what they define and the index templates composed of them.
+	`),
+	Example: utils.TrimAndIndent(`
+	# Retrieve all component templates.
+	esctl get component-templates
+
+	# Retrieve the component templates no index template is composed of.
+	esctl get component-templates --where 'USED-BY='
+	`),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := config.ParseConfigFile()
+		if err != nil {
+			return err
+		}
+
+		return showTable(cmd, func(ctx context.Context) (*output.Table, error) {
+			return componentTemplateTable(ctx, *config)
+		}, "NAME")
+	},
+}
+
+func init() {
+	getTemplatesCmd.Flags().StringVar(&flagTemplate, "template", "", "Name or pattern of the templates")
+	getComponentTemplatesCmd.Flags().StringVar(&flagTemplate, "template", "", "Name or pattern of the templates")
+}
+
+var templateColumns = []output.ColumnDefaults{
+	{Header: "NAME", Type: output.Text},
+	{Header: "INDEX-PATTERNS", Type: output.Text},
+	{Header: "PRIORITY", Type: output.Number},
+	{Header: "VERSION", Type: output.Number},
+	{Header: "COMPOSED-OF", Type: output.Text},
+	{Header: "DATA-STREAM", Type: output.Boolean, Wide: true},
+	{Header: "DESCRIPTION", Type: output.Text, Wide: true},
+}
+
+var componentTemplateColumns = []output.ColumnDefaults{
+	{Header: "NAME", Type: output.Text},
+	{Header: "VERSION", Type: output.Number},
+	{Header: "SETTINGS", Type: output.Boolean},
+	{Header: "MAPPINGS", Type: output.Boolean},
+	{Header: "ALIASES", Type: output.Boolean},
+	{Header: "USED-BY", Type: output.Text},
+	{Header: "DESCRIPTION", Type: output.Text, Wide: true},
+}
+
+func templateTable(ctx context.Context, conf config.Config) (*output.Table, error) {
+	templates, err := es.GetIndexTemplates(ctx, flagTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to retrieve index templates: %w", err)
+	}
+
+	columnDefs, err := getColumnDefs(conf, "template", templateColumns)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get column definitions: %w", err)
+	}
+
+	table := output.NewTable(columnDefs, "NAME")
+
+	for _, template := range templates {
+		rowData := map[string]string{
+			"NAME":           template.Name,
+			"INDEX-PATTERNS": strings.Join(template.IndexPatterns, ","),
+			"PRIORITY":       strconv.Itoa(template.Priority),
+			"VERSION":        versionOrEmpty(template.Version),
+			"COMPOSED-OF":    strings.Join(template.ComposedOf, ","),
+			"DATA-STREAM":    strconv.FormatBool(template.DataStream),
+			"DESCRIPTION":    template.Description(),
+		}
+
+		table.AddRow(template, rowData)
+	}
+
+	return table, filterRows(table, templateColumns)
+}
+
+func componentTemplateTable(ctx context.Context, conf config.Config) (*output.Table, error) {
+	templates, err := es.GetComponentTemplates(ctx, flagTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to retrieve component templates: %w", err)
+	}
+
+	columnDefs, err := getColumnDefs(conf, "component-template", componentTemplateColumns)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get column definitions: %w", err)
+	}
+
+	table := output.NewTable(columnDefs, "NAME")
+
+	for _, template := range templates {
+		rowData := map[string]string{
+			"NAME":        template.Name,
+			"VERSION":     versionOrEmpty(template.Version),
+			"SETTINGS":    strconv.FormatBool(template.Template.Settings != nil),
+			"MAPPINGS":    strconv.FormatBool(template.Template.Mappings != nil),
+			"ALIASES":     strconv.FormatBool(template.Template.Aliases != nil),
+			"USED-BY":     strings.Join(template.UsedBy, ","),
+			"DESCRIPTION": template.Description(),
+		}
+
+		table.AddRow(template, rowData)
+	}
+
+	return table, filterRows(table, componentTemplateColumns)
+}
+
+// versionOrEmpty leaves the version of a template empty when it has none.
+func versionOrEmpty(version int) string {
+	if version == 0 {
+		return ""
+	}
+	return strconv.Itoa(version)
+}
@@ -0,0 +1,12 @@
+package index
+
+var (
+	flagYes                 bool
+	flagAll                 bool
+	flagShards              int
+	flagDryRun              bool
+	flagMaxAge              string
+	flagMaxDocs             int
+	flagMaxSize             string
+	flagMaxPrimaryShardSize string
+)
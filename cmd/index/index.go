@@ -0,0 +1,109 @@
+package index
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/pincher95/esctl/es"
+	"github.com/pincher95/esctl/output"
+	"github.com/spf13/cobra"
+)
+
+var indexCmd = &cobra.Command{
+	Use:   "index",
+	Short: "Change the state of existing indices",
+	Long: utils.Trim(`
+The 'index' command allows you to change existing indices, after previewing them and asking for confirmation.
+
+Available Operations:
+  - open, close: Open closed indices or close indices, blocking reads and writes.
+  - freeze, unfreeze: Freeze or unfreeze indices (Elasticsearch 7.x).
+  - clone, shrink, split: Copy an index into a new index with the same, fewer or more primary shards.
+  - rollover: Roll an alias or data stream over to a new index.`),
+	Example: utils.TrimAndIndent(`
+# Close all indices matching a pattern, after confirming the preview.
+esctl index close 'logs-2023.*'`),
+}
+
+func init() {
+	indexCmd.PersistentFlags().BoolVarP(&flagYes, "yes", "y", false, "Skip the confirmation prompt")
+
+	indexCmd.AddCommand(
+		newStateCmd("open", "opened", "Open closed indices", es.OpenIndices, nil),
+		newStateCmd("close", "closed", "Close indices, blocking reads and writes", es.CloseIndices, nil),
+		newStateCmd("freeze", "frozen", "Freeze indices, making them read-only with a minimal memory footprint (Elasticsearch 7.x)", es.FreezeIndices, es.CheckFreezeSupported),
+		newStateCmd("unfreeze", "unfrozen", "Unfreeze frozen indices (Elasticsearch 7.x)", es.UnfreezeIndices, es.CheckFreezeSupported),
+		newResizeCmd(es.ResizeClone, "Copy an index into a new index with the same number of primary shards"),
+		newResizeCmd(es.ResizeShrink, "Copy an index into a new index with fewer primary shards"),
+		newResizeCmd(es.ResizeSplit, "Copy an index into a new index with more primary shards"),
+		rolloverCmd,
+	)
+}
+
+func Cmd() *cobra.Command {
+	return indexCmd
+}
+
+type indicesAction func(ctx context.Context, names []string) (*es.Acknowledgement, error)
+
+// newStateCmd builds a command running action on the indices matching a
+// pattern, after previewing them and asking for confirmation. The optional
+// supported check fails early on clusters without the action.
+func newStateCmd(verb, done, short string, action indicesAction, supported func(ctx context.Context) error) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   verb + " NAME",
+		Short: short,
+		Long: utils.Trim(fmt.Sprintf(`
+%s. NAME may be a pattern, e.g. 'logs-2023.*'.
+The matching indices are listed and changed by name only after confirmation.
+A pattern matching every index, e.g. '*' or '_all', also needs '--all'.`, short)),
+		Example: utils.TrimAndIndent(fmt.Sprintf(`
+	# %[2]s all indices matching a pattern.
+	esctl index %[1]s 'logs-2023.*'
+
+	# %[2]s an index without asking for confirmation.
+	esctl index %[1]s my_index --yes
+	`, verb, utils.Capitalize(verb))),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if supported != nil {
+				if err := supported(cmd.Context()); err != nil {
+					return err
+				}
+			}
+			return handleIndicesAction(cmd.Context(), args[0], verb, done, action)
+		},
+	}
+	cmd.Flags().BoolVar(&flagAll, "all", false, "Allow a pattern matching every index")
+	return cmd
+}
+
+func handleIndicesAction(ctx context.Context, pattern, verb, done string, action indicesAction) error {
+	indices, err := es.ResolveIndices(ctx, pattern, flagAll)
+	if err != nil {
+		return fmt.Errorf("Failed to retrieve indices: %w", err)
+	}
+
+	count := utils.Plural(len(indices), "index", "indices")
+	if err := utils.PrintIndexPreview("The following indices will be "+done+":", indices); err != nil {
+		return err
+	}
+	if err := utils.Confirm(utils.Capitalize(verb)+" "+count+"?", flagYes); err != nil {
+		return err
+	}
+
+	names := utils.IndexNames(indices)
+	response, err := action(ctx, names)
+	if err != nil {
+		return fmt.Errorf("Failed to %s %s: %w", verb, count, err)
+	}
+
+	if output.Format() != output.FormatTable && !output.IsWide() {
+		return output.PrintObject(response)
+	}
+	for _, name := range names {
+		fmt.Printf("index/%s %s\n", name, done)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/pincher95/esctl/es"
+	"github.com/pincher95/esctl/output"
+	"github.com/spf13/cobra"
+)
+
+var resizeRequirements = map[es.ResizeOperation]string{
+	es.ResizeClone:  "The source index must be read-only, e.g. by setting 'index.blocks.write' to true.",
+	es.ResizeShrink: "The source index must be read-only and a copy of every shard must be on the same node. The number of shards must be a factor of the source shards, 1 by default.",
+	es.ResizeSplit:  "The source index must be read-only. The number of shards must be a multiple of the source shards.",
+}
+
+// newResizeCmd builds the clone, shrink or split command copying an index
+// into a new index.
+func newResizeCmd(operation es.ResizeOperation, short string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   string(operation) + " SOURCE TARGET",
+		Short: short,
+		Long: utils.Trim(fmt.Sprintf(`
+%s.
+%s`, short, resizeRequirements[operation])),
+		Example: utils.TrimAndIndent(fmt.Sprintf(`
+	# %[2]s an index into a new index.
+	esctl index %[1]s my_index my_index-%[1]s --shards 2
+	`, operation, utils.Capitalize(string(operation)))),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return handleResize(cmd.Context(), operation, args[0], args[1])
+		},
+	}
+
+	if operation != es.ResizeClone {
+		cmd.Flags().IntVar(&flagShards, "shards", 0, "Number of primary shards of the target index")
+	}
+	if operation == es.ResizeSplit {
+		_ = cmd.MarkFlagRequired("shards")
+	}
+	return cmd
+}
+
+func handleResize(ctx context.Context, operation es.ResizeOperation, source, target string) error {
+	indices, err := es.ResolveIndices(ctx, source, false)
+	if err != nil {
+		return fmt.Errorf("Failed to retrieve indices: %w", err)
+	}
+	if len(indices) != 1 {
+		return fmt.Errorf("%s matches %s, %s needs exactly one source index", source, utils.Plural(len(indices), "index", "indices"), operation)
+	}
+
+	shards := flagShards
+	switch {
+	case operation == es.ResizeClone:
+		shards = indices[0].Primaries
+	case operation == es.ResizeShrink && shards == 0:
+		shards = 1
+	}
+	if err := utils.PrintIndexPreview(fmt.Sprintf("The following index will be copied into %s with %s:", target, utils.Plural(shards, "primary shard", "primary shards")), indices); err != nil {
+		return err
+	}
+	if err := utils.Confirm(fmt.Sprintf("%s %s into %s?", utils.Capitalize(string(operation)), indices[0].Name, target), flagYes); err != nil {
+		return err
+	}
+
+	response, err := es.ResizeIndex(ctx, operation, indices[0].Name, target, flagShards)
+	if err != nil {
+		return fmt.Errorf("Failed to %s index %s: %w", operation, indices[0].Name, err)
+	}
+
+	if output.Format() != output.FormatTable && !output.IsWide() {
+		return output.PrintObject(response)
+	}
+	fmt.Printf("index/%s created\n", target)
+	if !response.ShardsAcknowledged {
+		fmt.Fprintln(os.Stderr, "Warning: the shards of the new index were not started before the timeout")
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/pincher95/esctl/es"
+	"github.com/pincher95/esctl/output"
+	"github.com/spf13/cobra"
+)
+
+var rolloverCmd = &cobra.Command{
+	Use:   "rollover ALIAS [NEW_INDEX]",
+	Short: "Roll an alias or data stream over to a new index",
+	Long: utils.Trim(`
+Roll an alias or data stream over to a new index, when any of the given conditions is met or unconditionally without conditions.
+The rollover is evaluated with a dry run first, which shows the old and new index and the conditions met.
+NEW_INDEX is derived from the current write index when omitted.`),
+	Example: utils.TrimAndIndent(`
+	# Roll over an alias unconditionally.
+	esctl index rollover logs-write
+
+	# Roll over when the index is older than 7 days or its primary shards exceed 50gb.
+	esctl index rollover logs-write --max-age 7d --max-primary-shard-size 50gb
+
+	# Only show whether the alias would be rolled over.
+	esctl index rollover logs-write --max-docs 100000000 --dry-run
+	`),
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		newIndex := ""
+		if len(args) == 2 {
+			newIndex = args[1]
+		}
+		return handleRollover(cmd.Context(), args[0], newIndex)
+	},
+}
+
+func init() {
+	rolloverCmd.Flags().StringVar(&flagMaxAge, "max-age", "", "Roll over when the index is older than this, e.g. '7d'")
+	rolloverCmd.Flags().IntVar(&flagMaxDocs, "max-docs", 0, "Roll over when the index has more documents than this")
+	rolloverCmd.Flags().StringVar(&flagMaxSize, "max-size", "", "Roll over when the primary shards of the index are larger than this in total, e.g. '250gb'")
+	rolloverCmd.Flags().StringVar(&flagMaxPrimaryShardSize, "max-primary-shard-size", "", "Roll over when the largest primary shard is larger than this, e.g. '50gb'")
+	rolloverCmd.Flags().BoolVar(&flagDryRun, "dry-run", false, "Only show whether the alias would be rolled over")
+}
+
+func handleRollover(ctx context.Context, alias, newIndex string) error {
+	conditions := make(map[string]interface{})
+	if flagMaxAge != "" {
+		conditions["max_age"] = flagMaxAge
+	}
+	if flagMaxDocs > 0 {
+		conditions["max_docs"] = flagMaxDocs
+	}
+	if flagMaxSize != "" {
+		conditions["max_size"] = flagMaxSize
+	}
+	if flagMaxPrimaryShardSize != "" {
+		conditions["max_primary_shard_size"] = flagMaxPrimaryShardSize
+	}
+
+	preview, err := es.RolloverIndex(ctx, alias, newIndex, conditions, true)
+	if err != nil {
+		return fmt.Errorf("Failed to roll over %s: %w", alias, err)
+	}
+
+	if flagDryRun {
+		if output.Format() != output.FormatTable && !output.IsWide() {
+			return output.PrintObject(preview)
+		}
+		printRolloverPreview(os.Stdout, preview, alias)
+		return nil
+	}
+
+	printRolloverPreview(os.Stderr, preview, alias)
+	if len(conditions) > 0 && !conditionMet(preview.Conditions) {
+		return nil
+	}
+	if err := utils.Confirm(fmt.Sprintf("Roll over %s to %s?", alias, preview.NewIndex), flagYes); err != nil {
+		return err
+	}
+
+	response, err := es.RolloverIndex(ctx, alias, newIndex, conditions, false)
+	if err != nil {
+		return fmt.Errorf("Failed to roll over %s: %w", alias, err)
+	}
+
+	if output.Format() != output.FormatTable && !output.IsWide() {
+		return output.PrintObject(response)
+	}
+	if !response.RolledOver {
+		fmt.Printf("%s not rolled over, no condition was met\n", alias)
+		return nil
+	}
+	fmt.Printf("%s rolled over from index/%s to index/%s\n", alias, response.OldIndex, response.NewIndex)
+	return nil
+}
+
+// printRolloverPreview prints the old and new index of a dry run and which
+// conditions are met.
+func printRolloverPreview(out io.Writer, preview *es.RolloverResponse, alias string) {
+	fmt.Fprintf(out, "%s: %s -> %s\n", alias, preview.OldIndex, preview.NewIndex)
+
+	names := make([]string, 0, len(preview.Conditions))
+	for condition := range preview.Conditions {
+		names = append(names, condition)
+	}
+	sort.Strings(names)
+	for _, condition := range names {
+		state := "not met"
+		if preview.Conditions[condition] {
+			state = "met"
+		}
+		fmt.Fprintf(out, "  %s: %s\n", strings.Trim(condition, "[]"), state)
+	}
+
+	if len(names) > 0 && !conditionMet(preview.Conditions) {
+		fmt.Fprintf(out, "No condition is met, %s would not be rolled over.\n", alias)
+	}
+}
+
+func conditionMet(conditions map[string]bool) bool {
+	for _, met := range conditions {
+		if met {
+			return true
+		}
+	}
+	return false
+}
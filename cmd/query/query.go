@@ -2,7 +2,6 @@ package query
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/pincher95/esctl/cmd/utils"
 	"github.com/pincher95/esctl/es"
@@ -20,15 +19,14 @@ esctl query articles --id 61
 esctl query articles --term "price:10" --size 1
 esctl query articles --sort "price:desc" --from 10 --size 10`),
 	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		index := args[0]
 
-		response, err := es.SearchDocuments(index, flagId, flagTerm, flagFrom, flagSize, flagNested, flagSort)
+		response, err := es.SearchDocuments(cmd.Context(), index, flagId, flagTerm, flagFrom, flagSize, flagNested, flagSort)
 		if err != nil {
-			fmt.Fprintln(os.Stderr, "Failed to query:", err)
-			os.Exit(1)
+			return fmt.Errorf("Failed to query: %w", err)
 		}
-		output.PrintJson(response["hits"])
+		return output.PrintObject(response["hits"])
 	},
 }
 
@@ -0,0 +1,13 @@
+package restore
+
+var (
+	flagYes                bool
+	flagRepository         string
+	flagIndices            string
+	flagRenamePattern      string
+	flagRenameReplacement  string
+	flagIncludeGlobalState bool
+	flagIncludeAliases     bool
+	flagPartial            bool
+	flagWait               bool
+)
@@ -0,0 +1,29 @@
+package restore
+
+import (
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore Elasticsearch entities",
+	Long: utils.Trim(`
+The 'restore' command allows you to restore Elasticsearch entities.
+
+Available Entities:
+  - snapshot: Restore indices from a snapshot.`),
+	Example: utils.TrimAndIndent(`
+# Restore the logs indices of a snapshot under new names, after confirming the preview.
+esctl restore snapshot nightly-2024.05.01 --repo my_repo --indices 'logs-*' --rename-pattern '(.+)' --rename-replacement 'restored-$1'`),
+}
+
+func init() {
+	restoreCmd.PersistentFlags().BoolVarP(&flagYes, "yes", "y", false, "Skip the confirmation prompt")
+
+	restoreCmd.AddCommand(restoreSnapshotCmd)
+}
+
+func Cmd() *cobra.Command {
+	return restoreCmd
+}
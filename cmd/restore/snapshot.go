@@ -0,0 +1,160 @@
+package restore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/pincher95/esctl/es"
+	"github.com/pincher95/esctl/es/cat"
+	"github.com/pincher95/esctl/internal/client"
+	"github.com/pincher95/esctl/output"
+	"github.com/spf13/cobra"
+)
+
+var restoreSnapshotCmd = &cobra.Command{
+	Use:   "snapshot NAME",
+	Short: "Restore indices from a snapshot",
+	Long: utils.Trim(`
+Restore all or some indices of a snapshot. Indices are restored under their own name unless '--rename-pattern' matches them,
+then they are restored as '--rename-replacement', which may refer to groups of the pattern, e.g. '$1'.
+An index can only be restored over an existing index that is closed, which it replaces. The restore runs in the background
+unless '--wait' is given, use 'esctl get snapshot-progress --watch' to follow it.`),
+	Example: utils.TrimAndIndent(`
+	# Restore the logs indices of a snapshot next to the existing ones.
+	esctl restore snapshot nightly-2024.05.01 --repo my_repo --indices 'logs-*' --rename-pattern '(.+)' --rename-replacement 'restored-$1'
+
+	# Restore a closed index from a snapshot and wait until it is done.
+	esctl restore snapshot nightly-2024.05.01 --repo my_repo --indices logs-2024.05.01 --wait
+	`),
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return handleRestoreSnapshot(cmd.Context(), args[0])
+	},
+}
+
+func init() {
+	restoreSnapshotCmd.Flags().StringVarP(&flagRepository, "repo", "r", "", "Name of the snapshot repository")
+	restoreSnapshotCmd.Flags().StringVar(&flagIndices, "indices", "", "Comma-separated indices or patterns to restore, all indices of the snapshot by default")
+	restoreSnapshotCmd.Flags().StringVar(&flagRenamePattern, "rename-pattern", "", "Regular expression matching the indices to restore under a new name")
+	restoreSnapshotCmd.Flags().StringVar(&flagRenameReplacement, "rename-replacement", "", "New name of the indices matching '--rename-pattern'")
+	restoreSnapshotCmd.Flags().BoolVar(&flagIncludeGlobalState, "include-global-state", false, "Also restore the cluster state, e.g. templates and persistent settings")
+	restoreSnapshotCmd.Flags().BoolVar(&flagIncludeAliases, "include-aliases", true, "Restore the aliases of the indices")
+	restoreSnapshotCmd.Flags().BoolVar(&flagPartial, "partial", false, "Restore indices with shards missing from the snapshot")
+	restoreSnapshotCmd.Flags().BoolVar(&flagWait, "wait", false, "Wait for the restore to finish")
+	_ = restoreSnapshotCmd.MarkFlagRequired("repo")
+	restoreSnapshotCmd.MarkFlagsRequiredTogether("rename-pattern", "rename-replacement")
+}
+
+// restoreTarget is an index about to be restored and the state of the
+// existing index it replaces, if any.
+type restoreTarget struct {
+	es.RestoredIndex
+	Existing string
+}
+
+func handleRestoreSnapshot(ctx context.Context, name string) error {
+	request := es.RestoreSnapshotRequest{
+		Indices:            flagIndices,
+		RenamePattern:      flagRenamePattern,
+		RenameReplacement:  flagRenameReplacement,
+		IncludeGlobalState: flagIncludeGlobalState,
+		IncludeAliases:     flagIncludeAliases,
+		Partial:            flagPartial,
+	}
+
+	targets, err := restoreTargets(ctx, name, request)
+	if err != nil {
+		return err
+	}
+
+	if err := printRestorePreview(os.Stderr, name, targets); err != nil {
+		return err
+	}
+	for _, target := range targets {
+		if target.Existing == "open" {
+			return fmt.Errorf("cannot restore %s over the open index %s, close or delete it first, or restore it under another name with --rename-pattern", target.Index, target.RestoredAs)
+		}
+	}
+	prompt := fmt.Sprintf("Restore %s from snapshot %s?", utils.Plural(len(targets), "index", "indices"), name)
+	if flagIncludeGlobalState {
+		prompt = fmt.Sprintf("Restore %s and the cluster state from snapshot %s?", utils.Plural(len(targets), "index", "indices"), name)
+	}
+	if err := utils.Confirm(prompt, flagYes); err != nil {
+		return err
+	}
+
+	response, err := es.RestoreSnapshot(ctx, flagRepository, name, request, flagWait)
+	if err != nil {
+		return fmt.Errorf("Failed to restore snapshot %s: %w", name, err)
+	}
+
+	if output.Format() != output.FormatTable && !output.IsWide() {
+		return output.PrintObject(response)
+	}
+	if response.Snapshot == nil {
+		fmt.Printf("snapshot/%s restore started\n", name)
+		return nil
+	}
+	fmt.Printf("snapshot/%s restored, %d of %d shards successful\n", name, response.Snapshot.Shards.Successful, response.Snapshot.Shards.Total)
+	return nil
+}
+
+// restoreTargets lists the indices the restore request would restore, and
+// which of them would replace an existing index.
+func restoreTargets(ctx context.Context, name string, request es.RestoreSnapshotRequest) ([]restoreTarget, error) {
+	snapshots, err := es.GetSnapshots(ctx, flagRepository, name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to retrieve snapshot %s: %w", name, err)
+	}
+	if len(snapshots) == 0 {
+		return nil, &client.NotFoundError{Kind: "snapshot", Name: name}
+	}
+
+	restored, err := es.RestoredIndices(snapshots[0], request)
+	if err != nil {
+		return nil, err
+	}
+	if len(restored) == 0 && !request.IncludeGlobalState {
+		return nil, errors.New("no index of the snapshot matches --indices")
+	}
+
+	all := ""
+	indices, err := cat.CatIndices(ctx, nil, &all, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to retrieve indices: %w", err)
+	}
+	existing := make(map[string]string, len(indices))
+	for _, index := range indices {
+		existing[index.Index] = index.Status
+	}
+
+	targets := make([]restoreTarget, 0, len(restored))
+	for _, index := range restored {
+		targets = append(targets, restoreTarget{RestoredIndex: index, Existing: existing[index.RestoredAs]})
+	}
+	return targets, nil
+}
+
+// printRestorePreview prints the indices about to be restored and the
+// existing indices they replace.
+func printRestorePreview(out io.Writer, name string, targets []restoreTarget) error {
+	fmt.Fprintf(out, "The following indices will be restored from snapshot %s:\n", name)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  INDEX\tRESTORED-AS\tEXISTING")
+	for _, target := range targets {
+		existing := "-"
+		switch target.Existing {
+		case "close":
+			existing = "closed, will be replaced"
+		case "open":
+			existing = "open"
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", target.Index, target.RestoredAs, existing)
+	}
+	return w.Flush()
+}
@@ -3,17 +3,27 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pincher95/esctl/cmd/config"
 	"github.com/pincher95/esctl/cmd/count"
+	"github.com/pincher95/esctl/cmd/create"
+	"github.com/pincher95/esctl/cmd/delete"
 	"github.com/pincher95/esctl/cmd/describe"
+	"github.com/pincher95/esctl/cmd/explain"
 	"github.com/pincher95/esctl/cmd/get"
+	"github.com/pincher95/esctl/cmd/index"
 	"github.com/pincher95/esctl/cmd/query"
+	"github.com/pincher95/esctl/cmd/restore"
+	"github.com/pincher95/esctl/cmd/templates"
 	"github.com/pincher95/esctl/cmd/update"
 	"github.com/pincher95/esctl/constants"
 	"github.com/pincher95/esctl/internal/client"
+	"github.com/pincher95/esctl/output"
 	"github.com/pincher95/esctl/shared"
 	"github.com/spf13/cobra"
 )
@@ -21,46 +31,113 @@ import (
 var RootCmd = &cobra.Command{
 	Use:   "esctl",
 	Short: "esctl is CLI for Elasticsearch",
-	Long:  `esctl is a read-only CLI for Elasticsearch that allows users to manage and monitor their Elasticsearch clusters.`,
+	Long:  `esctl is a CLI for Elasticsearch that allows users to manage and monitor their Elasticsearch clusters. Commands that change the cluster show a preview and ask for confirmation first.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initialize(cmd)
+	},
+	// Errors are printed once by Execute, without the usage text
+	SilenceErrors: true,
+	SilenceUsage:  true,
 }
 
-func Execute(ctx context.Context) error {
-	return RootCmd.ExecuteContext(ctx)
+// Execute runs the command line and returns the process exit code.
+func Execute(ctx context.Context) int {
+	err := RootCmd.ExecuteContext(ctx)
+	if err == nil {
+		return exitOK
+	}
+
+	code := exitCode(err)
+	if code != exitInterrupted {
+		printError(err)
+	}
+	return code
 }
 
 func init() {
-	cobra.OnInitialize(initialize)
-
 	initProtocolFlag()
 	initHostFlag()
 	initPortFlag()
+	initCloudIDFlag()
 	initUsernameFlag()
 	initPasswordFlag()
+	initTokenFlags()
+	initTLSFlags()
+	initProxyFlag()
+	initRetryFlags()
+
+	RootCmd.PersistentFlags().StringArrayVar(&flagHeaders, "header", nil, "Extra HTTP header sent with every request as 'key=value', can be repeated")
 
+	RootCmd.PersistentFlags().StringVarP(&shared.OutputFormat, "output", "o", output.FormatTable, "Output format, one of: "+strings.Join(output.Formats, ", "))
 	RootCmd.PersistentFlags().StringVar(&shared.Context, "context", "", "Override context")
 	RootCmd.PersistentFlags().BoolVar(&shared.Debug, "debug", false, "Enable debug mode")
+	RootCmd.PersistentFlags().BoolVar(&shared.AllowWrites, "allow-writes", false, "Allow changes to the cluster of a read-only context")
 
 	RootCmd.AddCommand(config.Cmd())
 	RootCmd.AddCommand(count.Cmd())
+	RootCmd.AddCommand(create.Cmd())
+	RootCmd.AddCommand(delete.Cmd())
 	RootCmd.AddCommand(describe.Cmd())
+	RootCmd.AddCommand(explain.Cmd())
 	RootCmd.AddCommand(get.Cmd())
+	RootCmd.AddCommand(index.Cmd())
 	RootCmd.AddCommand(query.Cmd())
+	RootCmd.AddCommand(restore.Cmd())
+	RootCmd.AddCommand(templates.Cmd())
 	RootCmd.AddCommand(update.Cmd())
 }
 
-func initialize() {
-	if shared.ElasticsearchHost == "" {
-		conf := config.ParseConfigFile()
-		readContextFromConfig(*conf)
+var flagHeaders []string
+
+// envError holds the first invalid environment variable found while
+// defining the flags, reported once a command runs.
+var envError error
+
+func invalidEnv(name, value string) {
+	if envError == nil {
+		envError = config.Errorf("invalid value for %s environment variable: %s", name, value)
+	}
+}
+
+func initialize(cmd *cobra.Command) error {
+	if envError != nil {
+		return envError
+	}
+
+	if err := output.SetFormat(shared.OutputFormat); err != nil {
+		return err
 	}
 
-	initClient()
+	if shared.ElasticsearchHost == "" && shared.ElasticsearchCloudID == "" {
+		conf, err := config.ParseConfigFile()
+		if err != nil {
+			return err
+		}
+		if err := readContextFromConfig(cmd, *conf); err != nil {
+			return err
+		}
+	}
+
+	if shared.ElasticsearchCloudID != "" {
+		if err := applyCloudID(); err != nil {
+			return err
+		}
+	}
+
+	headers, err := parseHeaders(flagHeaders)
+	if err != nil {
+		return err
+	}
+	for key, value := range headers {
+		shared.ElasticsearchHeaders[key] = value
+	}
+
+	return initClient(opaqueID(cmd))
 }
 
-func readContextFromConfig(conf config.Config) {
+func readContextFromConfig(cmd *cobra.Command, conf config.Config) error {
 	if len(conf.Contexts) == 0 {
-		fmt.Println("Error: No contexts defined in the configuration.")
-		os.Exit(1)
+		return config.Errorf("no contexts defined in the configuration")
 	}
 
 	var context string
@@ -76,6 +153,9 @@ func readContextFromConfig(conf config.Config) {
 	clusterFound := false
 	for _, cluster := range conf.Contexts {
 		if cluster.Name == context {
+			if err := cluster.ResolveSecrets(); err != nil {
+				return &config.Error{Err: err}
+			}
 			shared.ElasticsearchProtocol = cluster.Protocol
 			if shared.ElasticsearchProtocol == "" {
 				shared.ElasticsearchProtocol = constants.DefaultElasticsearchProtocol
@@ -87,9 +167,35 @@ func readContextFromConfig(conf config.Config) {
 			shared.ElasticsearchUsername = cluster.Username
 			shared.ElasticsearchPassword = cluster.Password
 			shared.ElasticsearchHost = cluster.Host
-			if shared.ElasticsearchHost == "" {
-				fmt.Println("Error: 'host' field is not specified in the configuration for the current cluster.")
-				os.Exit(1)
+			if len(cluster.Hosts) > 0 {
+				shared.ElasticsearchHosts = make([]string, 0, len(cluster.Hosts))
+				for _, host := range cluster.Hosts {
+					shared.ElasticsearchHosts = append(shared.ElasticsearchHosts, nodeURL(host))
+				}
+			}
+			shared.ElasticsearchHostSelection = cluster.HostSelection
+			if shared.ElasticsearchCloudID == "" {
+				shared.ElasticsearchCloudID = cluster.CloudID
+			}
+			if shared.ElasticsearchHost == "" && len(shared.ElasticsearchHosts) == 0 && shared.ElasticsearchCloudID == "" {
+				return config.Errorf("'host', 'hosts' or 'cloud-id' field is not specified in the configuration for context '%s'", cluster.Name)
+			}
+			if shared.ElasticsearchAPIKey == "" {
+				shared.ElasticsearchAPIKey = cluster.APIKey
+			}
+			if shared.ElasticsearchToken == "" {
+				shared.ElasticsearchToken = cluster.BearerToken
+			}
+			applyContextTLS(cmd, cluster.TLS)
+			shared.ReadOnly = cluster.ReadOnly
+			if shared.ElasticsearchProxy == "" {
+				shared.ElasticsearchProxy = cluster.Proxy
+			}
+			for key, value := range cluster.Headers {
+				shared.ElasticsearchHeaders[key] = value
+			}
+			if err := applyContextRetries(cmd, cluster); err != nil {
+				return err
 			}
 			clusterFound = true
 			break
@@ -97,9 +203,9 @@ func readContextFromConfig(conf config.Config) {
 	}
 
 	if !clusterFound {
-		fmt.Printf("Error: No cluster found with the name '%s' in the configuration.\n", conf.CurrentContext)
-		os.Exit(1)
+		return config.Errorf("no context found with the name '%s' in the configuration", context)
 	}
+	return nil
 }
 
 func initProtocolFlag() {
@@ -122,14 +228,33 @@ func initPortFlag() {
 	if defaultPortStr != "" {
 		parsedPort, err := strconv.Atoi(defaultPortStr)
 		if err != nil || parsedPort <= 0 {
-			fmt.Printf("Invalid value for %s environment variable: %s\n", constants.ElasticsearchPortEnvVar, defaultPortStr)
-			os.Exit(1)
+			invalidEnv(constants.ElasticsearchPortEnvVar, defaultPortStr)
+		} else {
+			defaultPort = parsedPort
 		}
-		defaultPort = parsedPort
 	}
 	RootCmd.PersistentFlags().IntVar(&shared.ElasticsearchPort, "port", defaultPort, "Elasticsearch port")
 }
 
+func initCloudIDFlag() {
+	defaultCloudID := os.Getenv(constants.ElasticsearchCloudIDEnvVar)
+	RootCmd.PersistentFlags().StringVar(&shared.ElasticsearchCloudID, "cloud-id", defaultCloudID, "Elastic Cloud ID, decoded into protocol, host and port")
+}
+
+// applyCloudID replaces protocol, host and port with the endpoint encoded in the Cloud ID.
+func applyCloudID() error {
+	endpoint, err := client.DecodeCloudID(shared.ElasticsearchCloudID)
+	if err != nil {
+		return &config.Error{Err: err}
+	}
+
+	shared.ElasticsearchProtocol = endpoint.Protocol
+	shared.ElasticsearchHost = endpoint.Host
+	shared.ElasticsearchPort = endpoint.Port
+	shared.ElasticsearchHosts = nil
+	return nil
+}
+
 func initUsernameFlag() {
 	defaultUsername := os.Getenv(constants.ElasticsearchUsernameEnvVar)
 	RootCmd.PersistentFlags().StringVar(&shared.ElasticsearchUsername, "username", defaultUsername, "Elasticsearch username")
@@ -140,13 +265,197 @@ func initPasswordFlag() {
 	RootCmd.PersistentFlags().StringVar(&shared.ElasticsearchPassword, "password", defaultPassword, "Elasticsearch password")
 }
 
-func initClient() {
+func initTokenFlags() {
+	defaultAPIKey := os.Getenv(constants.ElasticsearchAPIKeyEnvVar)
+	RootCmd.PersistentFlags().StringVar(&shared.ElasticsearchAPIKey, "api-key", defaultAPIKey, "Elasticsearch API key (base64 encoded id:api_key)")
+
+	defaultToken := os.Getenv(constants.ElasticsearchTokenEnvVar)
+	RootCmd.PersistentFlags().StringVar(&shared.ElasticsearchToken, "token", defaultToken, "Elasticsearch bearer token")
+}
+
+func initTLSFlags() {
+	flags := RootCmd.PersistentFlags()
+	flags.StringVar(&shared.ElasticsearchCACert, "ca-cert", os.Getenv(constants.ElasticsearchCACertEnvVar), "Path to a PEM encoded CA bundle used to verify the cluster")
+	flags.StringVar(&shared.ElasticsearchClientCert, "client-cert", os.Getenv(constants.ElasticsearchClientCertEnvVar), "Path to a PEM encoded client certificate for mutual TLS")
+	flags.StringVar(&shared.ElasticsearchClientKey, "client-key", os.Getenv(constants.ElasticsearchClientKeyEnvVar), "Path to the PEM encoded key of the client certificate")
+	flags.StringVar(&shared.ElasticsearchServerName, "server-name", os.Getenv(constants.ElasticsearchServerNameEnvVar), "Server name used to verify the cluster certificate")
+
+	defaultInsecure := false
+	defaultInsecureStr := os.Getenv(constants.ElasticsearchInsecureEnvVar)
+	if defaultInsecureStr != "" {
+		parsedInsecure, err := strconv.ParseBool(defaultInsecureStr)
+		if err != nil {
+			invalidEnv(constants.ElasticsearchInsecureEnvVar, defaultInsecureStr)
+		} else {
+			defaultInsecure = parsedInsecure
+		}
+	}
+	flags.BoolVar(&shared.ElasticsearchInsecure, "insecure-skip-verify", defaultInsecure, "Skip verification of the cluster certificate")
+}
+
+// applyContextTLS fills TLS settings from the context that were not already
+// given as flags or environment variables.
+func applyContextTLS(cmd *cobra.Command, tls config.TLS) {
+	if shared.ElasticsearchCACert == "" {
+		shared.ElasticsearchCACert = tls.CACert
+	}
+	if shared.ElasticsearchClientCert == "" {
+		shared.ElasticsearchClientCert = tls.ClientCert
+	}
+	if shared.ElasticsearchClientKey == "" {
+		shared.ElasticsearchClientKey = tls.ClientKey
+	}
+	if shared.ElasticsearchServerName == "" {
+		shared.ElasticsearchServerName = tls.ServerName
+	}
+	if !explicitlySet(cmd, "insecure-skip-verify", constants.ElasticsearchInsecureEnvVar) {
+		shared.ElasticsearchInsecure = tls.InsecureSkipVerify
+	}
+}
+
+// nodeURL turns an entry of a context's hosts list into a base URL, filling
+// in the context protocol and port when the entry does not specify them.
+func nodeURL(host string) string {
+	if strings.Contains(host, "://") {
+		return strings.TrimRight(host, "/")
+	}
+
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return fmt.Sprintf("%s://%s", shared.ElasticsearchProtocol, host)
+	}
+
+	return fmt.Sprintf("%s://%s", shared.ElasticsearchProtocol, net.JoinHostPort(host, strconv.Itoa(shared.ElasticsearchPort)))
+}
+
+func initRetryFlags() {
+	flags := RootCmd.PersistentFlags()
+
+	defaultTimeout := constants.DefaultElasticsearchTimeout
+	if env := os.Getenv(constants.ElasticsearchTimeoutEnvVar); env != "" {
+		parsed, err := time.ParseDuration(env)
+		if err != nil || parsed < 0 {
+			invalidEnv(constants.ElasticsearchTimeoutEnvVar, env)
+		} else {
+			defaultTimeout = parsed
+		}
+	}
+	flags.DurationVar(&shared.ElasticsearchTimeout, "timeout", defaultTimeout, "Timeout of a single request to Elasticsearch, 0 disables it")
+
+	defaultRetries := constants.DefaultElasticsearchRetries
+	if env := os.Getenv(constants.ElasticsearchRetriesEnvVar); env != "" {
+		parsed, err := strconv.Atoi(env)
+		if err != nil || parsed < 0 {
+			invalidEnv(constants.ElasticsearchRetriesEnvVar, env)
+		} else {
+			defaultRetries = parsed
+		}
+	}
+	flags.IntVar(&shared.ElasticsearchRetries, "retries", defaultRetries, "Number of retries on connection errors and 429, 502, 503 or 504 responses")
+
+	defaultBackoff := constants.DefaultElasticsearchRetryBackoff
+	if env := os.Getenv(constants.ElasticsearchRetryBackoffEnvVar); env != "" {
+		parsed, err := time.ParseDuration(env)
+		if err != nil || parsed <= 0 {
+			invalidEnv(constants.ElasticsearchRetryBackoffEnvVar, env)
+		} else {
+			defaultBackoff = parsed
+		}
+	}
+	flags.DurationVar(&shared.ElasticsearchRetryBackoff, "retry-backoff", defaultBackoff, "Initial wait between retries, doubled after every attempt")
+}
+
+// applyContextRetries fills timeout and retry settings from the context that
+// were not already given as flags or environment variables.
+func applyContextRetries(cmd *cobra.Command, cluster config.Context) error {
+	if cluster.Timeout != "" && !explicitlySet(cmd, "timeout", constants.ElasticsearchTimeoutEnvVar) {
+		timeout, err := time.ParseDuration(cluster.Timeout)
+		if err != nil || timeout < 0 {
+			return config.Errorf("invalid timeout %q in context %q", cluster.Timeout, cluster.Name)
+		}
+		shared.ElasticsearchTimeout = timeout
+	}
+	if cluster.Retries != nil && !explicitlySet(cmd, "retries", constants.ElasticsearchRetriesEnvVar) {
+		if *cluster.Retries < 0 {
+			return config.Errorf("invalid retries %d in context %q", *cluster.Retries, cluster.Name)
+		}
+		shared.ElasticsearchRetries = *cluster.Retries
+	}
+	if cluster.RetryBackoff != "" && !explicitlySet(cmd, "retry-backoff", constants.ElasticsearchRetryBackoffEnvVar) {
+		backoff, err := time.ParseDuration(cluster.RetryBackoff)
+		if err != nil || backoff <= 0 {
+			return config.Errorf("invalid retry-backoff %q in context %q", cluster.RetryBackoff, cluster.Name)
+		}
+		shared.ElasticsearchRetryBackoff = backoff
+	}
+	return nil
+}
+
+// explicitlySet reports whether a flag with a built-in default was given on
+// the command line or through its environment variable.
+func explicitlySet(cmd *cobra.Command, flag, envVar string) bool {
+	return cmd.Flags().Changed(flag) || os.Getenv(envVar) != ""
+}
+
+func initProxyFlag() {
+	defaultProxy := os.Getenv(constants.ElasticsearchProxyEnvVar)
+	RootCmd.PersistentFlags().StringVar(&shared.ElasticsearchProxy, "proxy", defaultProxy, "HTTP or SOCKS5 proxy URL, e.g. 'socks5://localhost:1080'")
+}
+
+// parseHeaders parses 'key=value' pairs given with --header.
+func parseHeaders(pairs []string) (map[string]string, error) {
+	headers := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid header %q, expected key=value", pair)
+		}
+		headers[key] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
+
+// opaqueID identifies the running command in Elasticsearch slow logs and tasks, e.g. 'esctl/get/shards'.
+func opaqueID(cmd *cobra.Command) string {
+	return strings.Join(strings.Fields(cmd.CommandPath()), "/")
+}
+
+func initClient(opaqueID string) error {
 	baseURL := fmt.Sprintf("%s://%s:%d", shared.ElasticsearchProtocol, shared.ElasticsearchHost, shared.ElasticsearchPort)
+	if len(shared.ElasticsearchHosts) > 0 {
+		baseURL = shared.ElasticsearchHosts[0]
+	}
 
 	cfg := &client.Config{
-		BaseURL: baseURL,
-		Debug:   shared.Debug,
+		BaseURL:       baseURL,
+		Hosts:         shared.ElasticsearchHosts,
+		HostSelection: shared.ElasticsearchHostSelection,
+		Proxy:         shared.ElasticsearchProxy,
+		Headers:       shared.ElasticsearchHeaders,
+		OpaqueID:      opaqueID,
+		Username:      shared.ElasticsearchUsername,
+		Password:      shared.ElasticsearchPassword,
+		APIKey:        shared.ElasticsearchAPIKey,
+		BearerToken:   shared.ElasticsearchToken,
+		TLS: client.TLSConfig{
+			CACert:             shared.ElasticsearchCACert,
+			ClientCert:         shared.ElasticsearchClientCert,
+			ClientKey:          shared.ElasticsearchClientKey,
+			ServerName:         shared.ElasticsearchServerName,
+			InsecureSkipVerify: shared.ElasticsearchInsecure,
+		},
+		Timeout:       shared.ElasticsearchTimeout,
+		RetryCount:    shared.ElasticsearchRetries,
+		RetryWaitTime: shared.ElasticsearchRetryBackoff,
+		Debug:         shared.Debug,
+		ReadOnly:      shared.ReadOnly && !shared.AllowWrites,
+	}
+
+	c, err := client.NewClient(cfg)
+	if err != nil {
+		return config.Errorf("failed to create Elasticsearch client: %w", err)
 	}
 
-	shared.Client = client.NewClient(cfg)
+	shared.Client = c
+	return nil
 }
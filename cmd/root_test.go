@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pincher95/esctl/cmd/config"
+	"github.com/pincher95/esctl/constants"
+	"github.com/pincher95/esctl/shared"
+	"github.com/spf13/cobra"
+)
+
+func TestConfigCommandsSkipInitialize(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".config"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	conf := `current-context: broken
+contexts:
+  - name: broken
+    host: localhost
+    username: elastic
+    password-from:
+      command: ["false"]
+  - name: dev
+    host: localhost
+`
+	if err := os.WriteFile(filepath.Join(home, ".config", "esctl.yml"), []byte(conf), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Switching away from a context whose password helper fails must not
+	// run the helper
+	RootCmd.SetArgs([]string{"config", "use-context", "--name", "dev"})
+	defer RootCmd.SetArgs(nil)
+	if code := Execute(context.Background()); code != exitOK {
+		t.Fatalf("got exit code %d, want %d", code, exitOK)
+	}
+}
+
+func TestApplyContextTLSInsecure(t *testing.T) {
+	defer func(insecure bool) { shared.ElasticsearchInsecure = insecure }(shared.ElasticsearchInsecure)
+	t.Setenv(constants.ElasticsearchInsecureEnvVar, "")
+
+	testCases := []struct {
+		name    string
+		args    []string
+		context bool
+		want    bool
+	}{
+		{"context", nil, true, true},
+		{"no flag", nil, false, false},
+		{"flag disables", []string{"--insecure-skip-verify=false"}, true, false},
+		{"flag enables", []string{"--insecure-skip-verify"}, false, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().BoolVar(&shared.ElasticsearchInsecure, "insecure-skip-verify", false, "")
+			if err := cmd.ParseFlags(tc.args); err != nil {
+				t.Fatal(err)
+			}
+
+			applyContextTLS(cmd, config.TLS{InsecureSkipVerify: tc.context})
+			if shared.ElasticsearchInsecure != tc.want {
+				t.Errorf("got %v, want %v", shared.ElasticsearchInsecure, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package templates
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/pincher95/esctl/es"
+	"github.com/pincher95/esctl/output"
+	"github.com/spf13/cobra"
+)
+
+var templatesMatchCmd = &cobra.Command{
+	Use:   "match INDEX",
+	Short: "Show which index template a new index would be created with",
+	Long: utils.Trim(`
+Show which composable index template applies to a new index named INDEX, as simulated by Elasticsearch, and the
+templates overlapping with it. With '-o json' or '-o yaml' the settings, mappings and aliases the new index would get
+are included. When no composable template matches, legacy templates matching INDEX still apply.`),
+	Example: utils.TrimAndIndent(`
+	# Find the template a new logs index would get.
+	esctl templates match logs-2024.05.01
+	`),
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return handleTemplatesMatch(cmd.Context(), args[0])
+	},
+}
+
+func handleTemplatesMatch(ctx context.Context, index string) error {
+	match, err := es.MatchIndexTemplate(ctx, index)
+	if err != nil {
+		return fmt.Errorf("Failed to match index templates: %w", err)
+	}
+
+	if output.Format() != output.FormatTable && !output.IsWide() {
+		return output.PrintObject(match)
+	}
+	return printTemplateMatch(os.Stdout, match)
+}
+
+// printTemplateMatch prints the template a new index would get as aligned
+// 'Field: value' lines, with the templates overlapping with it.
+func printTemplateMatch(out io.Writer, m *es.IndexTemplateMatch) error {
+	if m.Resolved == nil {
+		fmt.Fprintf(out, "No composable index template matches %s, only legacy templates and the cluster defaults apply.\n", m.Index)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Index:\t%s\n", m.Index)
+	if t := m.Template; t != nil {
+		fmt.Fprintf(w, "Template:\t%s\n", t.Name)
+		fmt.Fprintf(w, "Index Patterns:\t%s\n", strings.Join(t.IndexPatterns, ", "))
+		fmt.Fprintf(w, "Priority:\t%d\n", t.Priority)
+		fmt.Fprintf(w, "Composed Of:\t%s\n", orNone(strings.Join(t.ComposedOf, ", ")))
+		fmt.Fprintf(w, "Data Stream:\t%t\n", t.DataStream)
+	} else {
+		fmt.Fprintf(w, "Template:\t<unknown>\n")
+	}
+
+	overlapping := make([]string, 0, len(m.Overlapping))
+	for _, o := range m.Overlapping {
+		overlapping = append(overlapping, fmt.Sprintf("%s (%s)", o.Name, strings.Join(o.IndexPatterns, ", ")))
+	}
+	fmt.Fprintf(w, "Overlapping:\t%s\n", orNone(strings.Join(overlapping, ", ")))
+	return w.Flush()
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
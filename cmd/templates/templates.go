@@ -0,0 +1,26 @@
+package templates
+
+import (
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+var templatesCmd = &cobra.Command{
+	Use:     "templates",
+	Aliases: []string{"template"},
+	Short:   "Work with composable index templates",
+	Long: utils.Trim(`
+The 'templates' command allows you to find out how index templates apply to new indices.
+Use 'esctl get templates' and 'esctl describe template' to list and inspect the templates themselves.`),
+	Example: utils.TrimAndIndent(`
+# Find out which index template a new index would be created with.
+esctl templates match logs-2024.05.01`),
+}
+
+func init() {
+	templatesCmd.AddCommand(templatesMatchCmd)
+}
+
+func Cmd() *cobra.Command {
+	return templatesCmd
+}
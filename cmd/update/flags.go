@@ -5,4 +5,13 @@ var (
 	flagDryRun      bool
 	flagExplain     bool
 	flagRetryFailed bool
+
+	flagYes            bool
+	flagIndex          string
+	flagShard          int
+	flagFromNode       string
+	flagToNode         string
+	flagNode           string
+	flagAllowPrimary   bool
+	flagAcceptDataLoss bool
 )
@@ -0,0 +1,88 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/pincher95/esctl/es"
+	"github.com/pincher95/esctl/output"
+	"github.com/spf13/cobra"
+)
+
+var updateLifecycleCmd = &cobra.Command{
+	Use:     "ilm",
+	Aliases: []string{"ism"},
+	Short:   "Changes the lifecycle state of indices",
+}
+
+var lifecycleRetryCmd = &cobra.Command{
+	Use:   "retry INDEX",
+	Short: "Re-run the failed lifecycle step of indices",
+	Long: utils.Trim(`
+Re-run the failed ILM step of the indices matching INDEX, or the failed ISM action on OpenSearch,
+after fixing the cause shown by 'esctl explain ilm'. Only indices stuck in a failed step are retried.`),
+	Example: utils.TrimAndIndent(`
+	# Retry the failed step of all logs indices.
+	esctl update ilm retry 'logs-*'
+	`),
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return handleLifecycleRetry(cmd.Context(), args[0])
+	},
+}
+
+func init() {
+	lifecycleRetryCmd.Flags().BoolVarP(&flagYes, "yes", "y", false, "Skip the confirmation prompt")
+
+	updateLifecycleCmd.AddCommand(lifecycleRetryCmd)
+}
+
+func handleLifecycleRetry(ctx context.Context, index string) error {
+	failed, err := es.ExplainLifecycle(ctx, index, true, true)
+	if err != nil {
+		return fmt.Errorf("Failed to explain lifecycle state: %w", err)
+	}
+	if len(failed) == 0 {
+		fmt.Fprintf(os.Stderr, "No index matching %s is in a failed lifecycle step.\n", index)
+		return nil
+	}
+
+	if err := printFailedLifecycles(os.Stderr, failed); err != nil {
+		return err
+	}
+	names := make([]string, len(failed))
+	for i, lifecycle := range failed {
+		names[i] = lifecycle.Index
+	}
+	if err := utils.Confirm(fmt.Sprintf("Retry the failed step of %s?", utils.Plural(len(names), "index", "indices")), flagYes); err != nil {
+		return err
+	}
+
+	if err := es.RetryLifecycle(ctx, names); err != nil {
+		return fmt.Errorf("Failed to retry lifecycle step: %w", err)
+	}
+
+	if output.Format() != output.FormatTable && !output.IsWide() {
+		return output.PrintObject(map[string][]string{"retried": names})
+	}
+	for _, name := range names {
+		fmt.Printf("index/%s retried\n", name)
+	}
+	return nil
+}
+
+// printFailedLifecycles lists the indices about to be retried with the step
+// they failed in and why.
+func printFailedLifecycles(out io.Writer, lifecycles []es.IndexLifecycle) error {
+	fmt.Fprintln(out, "The failed step of the following indices will be retried:")
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  INDEX\tPOLICY\tPHASE\tFAILED-STEP\tRETRIES\tSTEP-INFO")
+	for _, l := range lifecycles {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%d\t%s\n", l.Index, l.Policy, l.Phase, l.FailedStep, l.FailedStepRetryCount, l.StepInfoSummary())
+	}
+	return w.Flush()
+}
@@ -1,8 +1,8 @@
 package update
 
 import (
+	"context"
 	"fmt"
-	"os"
 
 	"github.com/pincher95/esctl/cmd/utils"
 	"github.com/pincher95/esctl/es/cluster"
@@ -14,8 +14,9 @@ var updateRerouteCmd = &cobra.Command{
 	Use:   "reroute",
 	Short: "Changes the allocation of shards in a cluster",
 	Long: utils.Trim(`
-	The reroute command allows for manual changes to the allocation of individual shards in the cluster..
-	`),
+The reroute command allows for manual changes to the allocation of individual shards in the cluster.
+Without a subcommand it retries the allocation of shards that failed too often.
+The subcommands move, cancel or allocate a single shard, showing the allocation decisions of a dry run first.`),
 	Example: utils.TrimAndIndent(`
 	# Reroute the shards in the cluster.
 	esctl update reroute
@@ -34,9 +35,15 @@ var updateRerouteCmd = &cobra.Command{
 
 	# Reroute the shards in the cluster with a dry-run, explanation, retry-failed, and metric.
 	esctl update reroute --dry-run --explain --retry-failed --metric 'none'
+
+	# Move shard 0 of an index to another node.
+	esctl update reroute move --index my_index --shard 0 --from es-data-0 --to es-data-1
+
+	# Show whether an unassigned replica could be allocated to a node.
+	esctl update reroute allocate-replica --index my_index --shard 0 --node es-data-1 --dry-run
 	`),
-	Run: func(cmd *cobra.Command, args []string) {
-		handleRerouteLogic()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return handleRerouteLogic(cmd.Context())
 	},
 }
 
@@ -47,12 +54,11 @@ func init() {
 	updateRerouteCmd.Flags().StringVar(&flagMertic, "metric", "none", "Limits the information returned to the specified metrics (Default: none)")
 }
 
-func handleRerouteLogic() {
-	reroute, err := cluster.ClusterReroute(nil, &flagMertic, flagDryRun, flagExplain, flagRetryFailed)
+func handleRerouteLogic(ctx context.Context) error {
+	reroute, err := cluster.ClusterReroute(ctx, nil, &flagMertic, flagDryRun, flagExplain, flagRetryFailed, nil)
 	if err != nil {
-		fmt.Fprintln(os.Stderr, "Failed to retrieve reroute:", err)
-		os.Exit(1)
+		return fmt.Errorf("Failed to retrieve reroute: %w", err)
 	}
 
-	output.PrintJson(reroute)
+	return output.PrintObject(reroute)
 }
@@ -0,0 +1,201 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pincher95/esctl/cmd/utils"
+	"github.com/pincher95/esctl/es/cluster"
+	"github.com/pincher95/esctl/output"
+	"github.com/spf13/cobra"
+)
+
+var rerouteMoveCmd = &cobra.Command{
+	Use:   "move",
+	Short: "Move a started shard from one node to another",
+	Example: utils.TrimAndIndent(`
+	# Move shard 0 of an index from one node to another.
+	esctl update reroute move --index my_index --shard 0 --from es-data-0 --to es-data-1
+	`),
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return handleRerouteCommand(cmd.Context(), cluster.RerouteCommand{
+			Name:     cluster.RerouteMove,
+			Index:    flagIndex,
+			Shard:    flagShard,
+			FromNode: flagFromNode,
+			ToNode:   flagToNode,
+		})
+	},
+}
+
+var rerouteCancelCmd = &cobra.Command{
+	Use:   "cancel",
+	Short: "Cancel the allocation or recovery of a shard on a node",
+	Long: utils.Trim(`
+Cancel the allocation or recovery of a shard on a node, e.g. a relocation or a slow replica recovery.
+Cancelling a primary shard needs '--allow-primary' and makes a replica the new primary.`),
+	Example: utils.TrimAndIndent(`
+	# Cancel the recovery of a replica of shard 0 on a node.
+	esctl update reroute cancel --index my_index --shard 0 --node es-data-1
+	`),
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return handleRerouteCommand(cmd.Context(), cluster.RerouteCommand{
+			Name:         cluster.RerouteCancel,
+			Index:        flagIndex,
+			Shard:        flagShard,
+			Node:         flagNode,
+			AllowPrimary: flagAllowPrimary,
+		})
+	},
+}
+
+var rerouteAllocateReplicaCmd = &cobra.Command{
+	Use:     "allocate-replica",
+	Aliases: []string{"allocate_replica"},
+	Short:   "Allocate an unassigned replica shard to a node",
+	Example: utils.TrimAndIndent(`
+	# Allocate an unassigned replica of shard 0 to a node.
+	esctl update reroute allocate-replica --index my_index --shard 0 --node es-data-1
+	`),
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return handleRerouteCommand(cmd.Context(), cluster.RerouteCommand{
+			Name:  cluster.RerouteAllocateReplica,
+			Index: flagIndex,
+			Shard: flagShard,
+			Node:  flagNode,
+		})
+	},
+}
+
+var rerouteAllocateStalePrimaryCmd = &cobra.Command{
+	Use:     "allocate-stale-primary",
+	Aliases: []string{"allocate_stale_primary"},
+	Short:   "Allocate a primary shard to a node holding a stale copy",
+	Long: utils.Trim(`
+Allocate a primary shard to a node holding a stale copy of it, when no up-to-date copy is left.
+Writes the stale copy missed are lost, so '--accept-data-loss' is required.`),
+	Example: utils.TrimAndIndent(`
+	# Promote the stale copy of shard 0 on a node to primary.
+	esctl update reroute allocate-stale-primary --index my_index --shard 0 --node es-data-1 --accept-data-loss
+	`),
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return handleRerouteCommand(cmd.Context(), cluster.RerouteCommand{
+			Name:           cluster.RerouteAllocateStalePrimary,
+			Index:          flagIndex,
+			Shard:          flagShard,
+			Node:           flagNode,
+			AcceptDataLoss: flagAcceptDataLoss,
+		})
+	},
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{rerouteMoveCmd, rerouteCancelCmd, rerouteAllocateReplicaCmd, rerouteAllocateStalePrimaryCmd} {
+		cmd.Flags().StringVar(&flagIndex, "index", "", "Name of the index")
+		cmd.Flags().IntVar(&flagShard, "shard", 0, "Number of the shard")
+		cmd.Flags().BoolVar(&flagDryRun, "dry-run", false, "Only show the allocation decisions without rerouting")
+		cmd.Flags().BoolVarP(&flagYes, "yes", "y", false, "Skip the confirmation prompt")
+		_ = cmd.MarkFlagRequired("index")
+		_ = cmd.MarkFlagRequired("shard")
+
+		updateRerouteCmd.AddCommand(cmd)
+	}
+
+	rerouteMoveCmd.Flags().StringVar(&flagFromNode, "from", "", "Node the shard is moved from")
+	rerouteMoveCmd.Flags().StringVar(&flagToNode, "to", "", "Node the shard is moved to")
+	_ = rerouteMoveCmd.MarkFlagRequired("from")
+	_ = rerouteMoveCmd.MarkFlagRequired("to")
+
+	for _, cmd := range []*cobra.Command{rerouteCancelCmd, rerouteAllocateReplicaCmd, rerouteAllocateStalePrimaryCmd} {
+		cmd.Flags().StringVar(&flagNode, "node", "", "Node of the shard")
+		_ = cmd.MarkFlagRequired("node")
+	}
+
+	rerouteCancelCmd.Flags().BoolVar(&flagAllowPrimary, "allow-primary", false, "Allow cancelling the allocation of a primary shard")
+	rerouteAllocateStalePrimaryCmd.Flags().BoolVar(&flagAcceptDataLoss, "accept-data-loss", false, "Acknowledge that writes missed by the stale copy are lost")
+	_ = rerouteAllocateStalePrimaryCmd.MarkFlagRequired("accept-data-loss")
+}
+
+// handleRerouteCommand evaluates a reroute command with a dry run, shows the
+// allocation decisions, and runs it once confirmed.
+func handleRerouteCommand(ctx context.Context, command cluster.RerouteCommand) error {
+	metric := "none"
+	commands := []cluster.RerouteCommand{command}
+
+	preview, err := cluster.ClusterReroute(ctx, nil, &metric, true, true, false, commands)
+	if err != nil {
+		return fmt.Errorf("Failed to evaluate reroute: %w", err)
+	}
+
+	if flagDryRun {
+		if output.Format() != output.FormatTable && !output.IsWide() {
+			return output.PrintObject(preview)
+		}
+		return printRerouteExplanations(os.Stdout, preview.Explanations)
+	}
+
+	if err := printRerouteExplanations(os.Stderr, preview.Explanations); err != nil {
+		return err
+	}
+	for _, explanation := range preview.Explanations {
+		if !explanation.Allowed() {
+			return fmt.Errorf("the %s command is rejected by the allocation deciders", command.Name)
+		}
+	}
+	prompt, done := rerouteSummary(command)
+	if err := utils.Confirm(prompt, flagYes); err != nil {
+		return err
+	}
+
+	reroute, err := cluster.ClusterReroute(ctx, nil, &metric, false, true, false, commands)
+	if err != nil {
+		return fmt.Errorf("Failed to reroute shard %s[%d]: %w", command.Index, command.Shard, err)
+	}
+
+	if output.Format() != output.FormatTable && !output.IsWide() {
+		return output.PrintObject(reroute)
+	}
+	fmt.Println(done)
+	return nil
+}
+
+// printRerouteExplanations prints the decision of every allocation decider
+// on every command as a table.
+func printRerouteExplanations(out io.Writer, explanations []cluster.RerouteExplanation) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "COMMAND\tDECIDER\tDECISION\tEXPLANATION")
+	for _, explanation := range explanations {
+		for _, decision := range explanation.Decisions {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", explanation.Command, decision.Decider, decision.Decision, strings.Join(strings.Fields(decision.Explanation), " "))
+		}
+	}
+	return w.Flush()
+}
+
+// rerouteSummary describes a reroute command for the confirmation prompt,
+// e.g. 'Move logs[0] from n1 to n2?', and once it is done.
+func rerouteSummary(command cluster.RerouteCommand) (prompt, done string) {
+	shard := fmt.Sprintf("%s[%d]", command.Index, command.Shard)
+	switch command.Name {
+	case cluster.RerouteMove:
+		return fmt.Sprintf("Move %s from %s to %s?", shard, command.FromNode, command.ToNode),
+			fmt.Sprintf("shard %s moving from %s to %s", shard, command.FromNode, command.ToNode)
+	case cluster.RerouteCancel:
+		return fmt.Sprintf("Cancel the allocation of %s on %s?", shard, command.Node),
+			fmt.Sprintf("shard %s cancelled on %s", shard, command.Node)
+	case cluster.RerouteAllocateReplica:
+		return fmt.Sprintf("Allocate a replica of %s on %s?", shard, command.Node),
+			fmt.Sprintf("shard %s replica allocated on %s", shard, command.Node)
+	default:
+		return fmt.Sprintf("Allocate the stale copy of %s on %s as primary, losing the writes it missed?", shard, command.Node),
+			fmt.Sprintf("shard %s stale primary allocated on %s", shard, command.Node)
+	}
+}
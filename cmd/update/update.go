@@ -12,10 +12,14 @@ var updateCmd = &cobra.Command{
 The 'update' command allows you to update Elasticsearch entities.
 
 Available Entities:
-  - reroute: Changes the allocation of shards in a cluster.`),
+  - reroute: Changes the allocation of shards in a cluster.
+  - ilm: Changes the lifecycle state of indices, e.g. retries failed steps.`),
 	Example: utils.TrimAndIndent(`
 # Reroute the shards in the cluster.
 esctl update reroute
+
+# Retry the failed lifecycle step of indices.
+esctl update ilm retry 'logs-*'
 	`),
 }
 
@@ -26,6 +30,7 @@ func init() {
 	// updateCmd.PersistentFlags().DurationVar(&flagRefreshInterval, "interval", 5*time.Second, "Interval between consecutive fetches")
 
 	updateCmd.AddCommand(updateRerouteCmd)
+	updateCmd.AddCommand(updateLifecycleCmd)
 
 }
 
@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pincher95/esctl/es"
+	"github.com/pincher95/esctl/internal/client"
+	"github.com/pincher95/esctl/shared"
+	"golang.org/x/term"
+)
+
+// ErrAborted is returned when the user declines a confirmation prompt.
+var ErrAborted = errors.New("aborted")
+
+// Confirm asks on stderr whether to go ahead with a change, unless yes is
+// set by '--yes'. Without a terminal to ask on it refuses to go ahead, as it
+// does right away for a read-only context.
+func Confirm(prompt string, yes bool) error {
+	if shared.Client != nil && shared.Client.ReadOnly() {
+		return &client.ReadOnlyError{}
+	}
+	if yes {
+		return nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return errors.New("refusing to make changes without confirmation, stdin is not a terminal; pass --yes to confirm")
+	}
+	return confirm(os.Stdin, os.Stderr, prompt)
+}
+
+func confirm(in io.Reader, out io.Writer, prompt string) error {
+	fmt.Fprintf(out, "%s [y/N]: ", prompt)
+
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	default:
+		return ErrAborted
+	}
+}
+
+// PrintIndexPreview prints the indices a command is about to change on
+// stderr, keeping stdout for the result.
+func PrintIndexPreview(title string, indices []es.IndexTarget) error {
+	return printIndexPreview(os.Stderr, title, indices)
+}
+
+func printIndexPreview(out io.Writer, title string, indices []es.IndexTarget) error {
+	fmt.Fprintln(out, title)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  INDEX\tHEALTH\tSTATUS\tPRI\tREP\tDOCS\tSTORE")
+	for _, index := range indices {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%d\t%d\t%d\t%s\n", index.Name, index.Health, index.Status, index.Primaries, index.Replicas, index.DocsCount, index.StoreSize)
+	}
+	return w.Flush()
+}
+
+// IndexNames returns the names of indices, e.g. the ones previewed.
+func IndexNames(indices []es.IndexTarget) []string {
+	names := make([]string, len(indices))
+	for i, index := range indices {
+		names[i] = index.Name
+	}
+	return names
+}
+
+// Plural returns 'n noun' with an 's' for anything but one, e.g. '2 indices'.
+func Plural(n int, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
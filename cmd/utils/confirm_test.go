@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pincher95/esctl/es"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		answer string
+		want   error
+	}{
+		{"y\n", nil},
+		{"YES\n", nil},
+		{" yes \n", nil},
+		{"n\n", ErrAborted},
+		{"\n", ErrAborted},
+		{"", ErrAborted},
+		{"yep\n", ErrAborted},
+	}
+
+	for _, test := range tests {
+		var out bytes.Buffer
+		err := confirm(strings.NewReader(test.answer), &out, "Delete 2 indices?")
+		if !errors.Is(err, test.want) {
+			t.Errorf("answer %q: got %v, want %v", test.answer, err, test.want)
+		}
+		if out.String() != "Delete 2 indices? [y/N]: " {
+			t.Errorf("answer %q: got prompt %q", test.answer, out.String())
+		}
+	}
+}
+
+func TestPrintIndexPreview(t *testing.T) {
+	var out bytes.Buffer
+	err := printIndexPreview(&out, "The following indices will be deleted:", []es.IndexTarget{
+		{Name: "logs-1", Health: "green", Status: "open", Primaries: 1, Replicas: 1, DocsCount: 10, StoreSize: "1kb"},
+		{Name: "logs-2", Health: "", Status: "close"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `The following indices will be deleted:
+  INDEX   HEALTH  STATUS  PRI  REP  DOCS  STORE
+  logs-1  green   open    1    1    10    1kb
+  logs-2          close   0    0    0     
+`
+	if out.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", out.String(), want)
+	}
+}
@@ -1,6 +1,9 @@
 package utils
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 const Indentation = "  "
 
@@ -19,6 +22,14 @@ func TrimAndIndent(s string) string {
 	return strings.Join(indentedLines, "\n")
 }
 
+// Capitalize upper-cases the first letter of s, e.g. for a verb starting a prompt.
+func Capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
 func SafeString(s *string) string {
 	if s == nil {
 		return "" // or "<nil>" or whatever default you want
@@ -32,3 +43,18 @@ func SafeInt(i *int) int {
 	}
 	return *i
 }
+
+// FormatBytes formats a byte count the way Elasticsearch does, e.g. '1.5gb'.
+func FormatBytes(bytes int64) string {
+	units := []string{"b", "kb", "mb", "gb", "tb", "pb"}
+	size := float64(bytes)
+	unit := 0
+	for size >= 1024 && unit < len(units)-1 {
+		size /= 1024
+		unit++
+	}
+	if unit == 0 {
+		return fmt.Sprintf("%d%s", bytes, units[unit])
+	}
+	return fmt.Sprintf("%.1f%s", size, units[unit])
+}
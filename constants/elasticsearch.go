@@ -1,11 +1,28 @@
 package constants
 
+import "time"
+
 const (
-	ElasticsearchProtocolEnvVar  = "ESCTL_PROTOCOL"
-	ElasticsearchUsernameEnvVar  = "ESCTL_USERNAME"
-	ElasticsearchPasswordEnvVar  = "ESCTL_PASSWORD"
-	ElasticsearchHostEnvVar      = "ESCTL_HOST"
-	ElasticsearchPortEnvVar      = "ESCTL_PORT"
-	DefaultElasticsearchProtocol = "http"
-	DefaultElasticsearchPort     = 9200
+	ElasticsearchProtocolEnvVar      = "ESCTL_PROTOCOL"
+	ElasticsearchUsernameEnvVar      = "ESCTL_USERNAME"
+	ElasticsearchPasswordEnvVar      = "ESCTL_PASSWORD"
+	ElasticsearchAPIKeyEnvVar        = "ESCTL_API_KEY"
+	ElasticsearchTokenEnvVar         = "ESCTL_TOKEN"
+	ElasticsearchHostEnvVar          = "ESCTL_HOST"
+	ElasticsearchPortEnvVar          = "ESCTL_PORT"
+	ElasticsearchCloudIDEnvVar       = "ESCTL_CLOUD_ID"
+	ElasticsearchProxyEnvVar         = "ESCTL_PROXY"
+	ElasticsearchCACertEnvVar        = "ESCTL_CA_CERT"
+	ElasticsearchClientCertEnvVar    = "ESCTL_CLIENT_CERT"
+	ElasticsearchClientKeyEnvVar     = "ESCTL_CLIENT_KEY"
+	ElasticsearchInsecureEnvVar      = "ESCTL_INSECURE_SKIP_VERIFY"
+	ElasticsearchServerNameEnvVar    = "ESCTL_SERVER_NAME"
+	ElasticsearchTimeoutEnvVar       = "ESCTL_TIMEOUT"
+	ElasticsearchRetriesEnvVar       = "ESCTL_RETRIES"
+	ElasticsearchRetryBackoffEnvVar  = "ESCTL_RETRY_BACKOFF"
+	DefaultElasticsearchProtocol     = "http"
+	DefaultElasticsearchPort         = 9200
+	DefaultElasticsearchTimeout      = 30 * time.Second
+	DefaultElasticsearchRetries      = 3
+	DefaultElasticsearchRetryBackoff = 500 * time.Millisecond
 )
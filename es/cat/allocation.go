@@ -1,8 +1,10 @@
 package cat
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/pincher95/esctl/internal/client"
 	"github.com/pincher95/esctl/shared"
 )
 
@@ -19,7 +21,7 @@ type Allocation struct {
 	Node        string  `json:"node"`
 }
 
-func CatAllocation(endpoint, nodeID, bytes *string) ([]Allocation, error) {
+func CatAllocation(ctx context.Context, endpoint, nodeID, bytes *string) ([]Allocation, error) {
 	if endpoint == nil {
 		endpoint = new(string)
 		*endpoint = "_cat/allocation?format=json&h=shards,disk.indices,disk.used,disk.avail,disk.total,host,ip,node,disk.percent"
@@ -35,13 +37,13 @@ func CatAllocation(endpoint, nodeID, bytes *string) ([]Allocation, error) {
 
 	allocations := make([]Allocation, 0)
 
-	resp, err := shared.Client.R().SetHeader("Content-Type", "application/json").SetResult(&allocations).Get(*endpoint)
+	resp, err := shared.Client.R().SetContext(ctx).SetResult(&allocations).Get(*endpoint)
 	if err != nil {
 		return nil, err
 	}
 
 	if resp.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to get nodes allocations: %s", resp.Status())
+		return nil, fmt.Errorf("failed to get nodes allocations: %w", client.NewResponseError(resp))
 	}
 
 	return allocations, nil
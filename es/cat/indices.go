@@ -1,8 +1,10 @@
 package cat
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/pincher95/esctl/internal/client"
 	"github.com/pincher95/esctl/shared"
 )
 
@@ -155,14 +157,26 @@ type Indice struct {
 	SearchThrottled                      bool    `json:"search.throttled,string"`
 }
 
-func CatIndices(endpoint, index, bytes *string) ([]Indice, error) {
+// IndicesEndpoint returns the default _cat/indices endpoint for index, all
+// indices when empty, limited to indices with the given health when set.
+func IndicesEndpoint(index, health string) string {
+	endpoint := "_cat/indices?format=json&h=health,status,index,uuid,pri,rep,docs.count,docs.deleted,creation.date.string,store.size,pri.store.size"
+
+	if index != "" {
+		endpoint = fmt.Sprintf("_cat/indices/%s?format=json&h=health,status,index,uuid,pri,rep,docs.count,docs.deleted,creation.date.string,store.size,pri.store.size", index)
+	}
+
+	if health != "" {
+		endpoint += fmt.Sprintf("&health=%s", health)
+	}
+
+	return endpoint
+}
+
+func CatIndices(ctx context.Context, endpoint, index, bytes *string) ([]Indice, error) {
 	if endpoint == nil {
 		endpoint = new(string)
-		*endpoint = "_cat/indices?format=json&h=health,status,index,uuid,pri,rep,docs.count,docs.deleted,creation.date.string,store.size,pri.store.size"
-
-		if *index != "" {
-			*endpoint = fmt.Sprintf("_cat/indices/%s?format=json&h=health,status,index,uuid,pri,rep,docs.count,docs.deleted,creation.date.string,store.size,pri.store.size", *index)
-		}
+		*endpoint = IndicesEndpoint(*index, "")
 	}
 
 	if bytes != nil {
@@ -171,13 +185,13 @@ func CatIndices(endpoint, index, bytes *string) ([]Indice, error) {
 
 	indices := make([]Indice, 0)
 
-	resp, err := shared.Client.R().SetHeader("Content-Type", "application/json").SetResult(&indices).Get(*endpoint)
+	resp, err := shared.Client.R().SetContext(ctx).SetResult(&indices).Get(*endpoint)
 	if err != nil {
 		return nil, err
 	}
 
 	if resp.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to get indices: %s", resp.Status())
+		return nil, fmt.Errorf("failed to get indices: %w", client.NewResponseError(resp))
 	}
 
 	return indices, nil
@@ -1,9 +1,12 @@
 package cat
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
+	"github.com/pincher95/esctl/es/distribution"
+	"github.com/pincher95/esctl/internal/client"
 	"github.com/pincher95/esctl/shared"
 )
 
@@ -109,10 +112,25 @@ type Node struct {
 	SuggestTotal                    *int    `json:"suggest.total,string"`
 }
 
-func CatNodes(endpoint, nodeName, bytes, time *string) ([]Node, error) {
+// nodeColumns lists the _cat/nodes columns the cluster knows. Only OpenSearch
+// has node.roles, and OpenSearch 2 renamed master to cluster_manager.
+func nodeColumns(info distribution.Info) []string {
+	columns := []string{"name", "ip", "node.role"}
+	switch {
+	case info.UsesClusterManager():
+		columns = append(columns, "node.roles", "cluster_manager")
+	case info.IsOpenSearch():
+		columns = append(columns, "node.roles", "master")
+	default:
+		columns = append(columns, "master")
+	}
+	return append(columns, "heap.percent", "cpu", "load_1m", "load_5m", "load_15m", "ram.percent")
+}
+
+func CatNodes(ctx context.Context, endpoint, nodeName, bytes, time *string) ([]Node, error) {
 	if endpoint == nil {
 		endpoint = new(string)
-		*endpoint = "_cat/nodes?format=json&h=name,ip,node.role,node.roles,master,heap.percent,cpu,load_1m,load_5m,load_15m,ram.percent"
+		*endpoint = "_cat/nodes?format=json&h=" + strings.Join(nodeColumns(distribution.Detect(ctx)), ",")
 	}
 
 	if bytes != nil {
@@ -125,13 +143,13 @@ func CatNodes(endpoint, nodeName, bytes, time *string) ([]Node, error) {
 
 	nodes := make([]Node, 0)
 
-	resp, err := shared.Client.R().SetHeader("Content-Type", "application/json").SetResult(&nodes).Get(*endpoint)
+	resp, err := shared.Client.R().SetContext(ctx).SetResult(&nodes).Get(*endpoint)
 	if err != nil {
 		return nil, err
 	}
 
 	if resp.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to get nodes: %s", resp.Status())
+		return nil, fmt.Errorf("failed to get nodes: %w", client.NewResponseError(resp))
 	}
 
 	if nodeName != nil {
@@ -145,7 +163,7 @@ func CatNodes(endpoint, nodeName, bytes, time *string) ([]Node, error) {
 
 		// If no matches found, return error
 		if len(filtered) == 0 {
-			return nil, fmt.Errorf("node not found: %s", *nodeName)
+			return nil, &client.NotFoundError{Kind: "node", Name: *nodeName}
 		}
 
 		// Replace the original slice with the filtered one
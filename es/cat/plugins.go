@@ -1,8 +1,10 @@
 package cat
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/pincher95/esctl/internal/client"
 	"github.com/pincher95/esctl/shared"
 )
 
@@ -14,7 +16,7 @@ type Plugin struct {
 	Description string `json:"description,omitempty"`
 }
 
-func CatPlugins(endpoint *string) ([]Plugin, error) {
+func CatPlugins(ctx context.Context, endpoint *string) ([]Plugin, error) {
 	if endpoint == nil {
 		endpoint = new(string)
 		*endpoint = "_cat/plugins?format=json&h=id,name,component,version,description"
@@ -22,13 +24,13 @@ func CatPlugins(endpoint *string) ([]Plugin, error) {
 
 	plugins := make([]Plugin, 0)
 
-	resp, err := shared.Client.R().SetHeader("Content-Type", "application/json").SetResult(&plugins).Get(*endpoint)
+	resp, err := shared.Client.R().SetContext(ctx).SetResult(&plugins).Get(*endpoint)
 	if err != nil {
 		return nil, err
 	}
 
 	if resp.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to get nodes plugins: %s", resp.Status())
+		return nil, fmt.Errorf("failed to get nodes plugins: %w", client.NewResponseError(resp))
 	}
 
 	return plugins, nil
@@ -1,8 +1,10 @@
 package cat
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/pincher95/esctl/internal/client"
 	"github.com/pincher95/esctl/shared"
 )
 
@@ -89,7 +91,7 @@ type Shard struct {
 	DocsDeleted                    *int    `json:"docs.deleted,string"`
 }
 
-func CatShards(endpoint, index, bytes, time *string) ([]Shard, error) {
+func CatShards(ctx context.Context, endpoint, index, bytes, time *string) ([]Shard, error) {
 	if endpoint == nil {
 		endpoint = new(string)
 		*endpoint = "_cat/shards?format=json&h=index,shard,prirep,state,docs,store,ip,id,node,unassigned.reason,unassigned.at,segments.count"
@@ -109,13 +111,13 @@ func CatShards(endpoint, index, bytes, time *string) ([]Shard, error) {
 
 	shards := make([]Shard, 0)
 
-	resp, err := shared.Client.R().SetHeader("Content-Type", "application/json").SetResult(&shards).Get(*endpoint)
+	resp, err := shared.Client.R().SetContext(ctx).SetResult(&shards).Get(*endpoint)
 	if err != nil {
 		return nil, err
 	}
 
 	if resp.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to get shards: %s", resp.Status())
+		return nil, fmt.Errorf("failed to get shards: %w", client.NewResponseError(resp))
 	}
 
 	return shards, nil
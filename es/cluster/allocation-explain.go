@@ -1,8 +1,10 @@
 package cluster
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/pincher95/esctl/internal/client"
 	"github.com/pincher95/esctl/shared"
 )
 
@@ -59,7 +61,7 @@ type ClusterAllocationExplainDeciders struct {
 	Explanation string `json:"explanation"`
 }
 
-func ClusterAllocationExplain(endpoint *string, includeDiskInfo, includeYesDecisions bool) (*AllocationExplain, error) {
+func ClusterAllocationExplain(ctx context.Context, endpoint *string, includeDiskInfo, includeYesDecisions bool) (*AllocationExplain, error) {
 	if endpoint == nil {
 		endpoint = new(string)
 		*endpoint = "_cluster/allocation/explain?format=json"
@@ -75,14 +77,13 @@ func ClusterAllocationExplain(endpoint *string, includeDiskInfo, includeYesDecis
 
 	var allocation AllocationExplain
 
-	resp, err := shared.Client.R().SetHeader("Content-Type", "application/json").SetResult(&allocation).Get(*endpoint)
+	resp, err := shared.Client.R().SetContext(ctx).SetResult(&allocation).Get(*endpoint)
 	if err != nil {
 		return nil, err
 	}
 
 	if resp.StatusCode() != 200 {
-		// return nil, fmt.Errorf("failed to get cluster allocation explain: %s", resp.Status())
-		return nil, fmt.Errorf("\n%s", resp.String())
+		return nil, fmt.Errorf("failed to get cluster allocation explain: %w", client.NewResponseError(resp))
 	}
 
 	return &allocation, nil
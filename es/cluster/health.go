@@ -1,19 +1,22 @@
 package cluster
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/pincher95/esctl/internal/client"
 	"github.com/pincher95/esctl/shared"
 )
 
 type Health struct {
-	ClusterName                 string                 `json:"cluster_name"`
-	Status                      string                 `json:"status"`
-	TimedOut                    bool                   `json:"timed_out"`
-	NumberOfNodes               int                    `json:"number_of_nodes"`
-	NumberOfDataNodes           int                    `json:"number_of_data_nodes"`
-	DiscoveredMaster            bool                   `json:"discovered_master"`
-	DiscoveredClusterManager    bool                   `json:"discovered_cluster_manager"`
+	ClusterName       string `json:"cluster_name"`
+	Status            string `json:"status"`
+	TimedOut          bool   `json:"timed_out"`
+	NumberOfNodes     int    `json:"number_of_nodes"`
+	NumberOfDataNodes int    `json:"number_of_data_nodes"`
+	// Elasticsearch and OpenSearch 1 report discovered_master, OpenSearch 2 discovered_cluster_manager
+	DiscoveredMaster            *bool                  `json:"discovered_master,omitempty"`
+	DiscoveredClusterManager    *bool                  `json:"discovered_cluster_manager,omitempty"`
 	ActivePrimaryShards         int                    `json:"active_primary_shards"`
 	ActiveShards                int                    `json:"active_shards"`
 	RelocatingShards            int                    `json:"relocating_shards"`
@@ -54,7 +57,7 @@ type ShardHealth struct {
 	UnassingedPrimaryShards int    `json:"unassinged_primary_shards"`
 }
 
-func ClusterHealth(endpoint, level, expand, index *string) (*Health, error) {
+func ClusterHealth(ctx context.Context, endpoint, level, expand, index *string) (*Health, error) {
 	if endpoint == nil {
 		endpoint = new(string)
 		*endpoint = "_cluster/health?format=json"
@@ -74,13 +77,13 @@ func ClusterHealth(endpoint, level, expand, index *string) (*Health, error) {
 
 	var health Health
 
-	resp, err := shared.Client.R().SetHeader("Content-Type", "application/json").SetResult(&health).Get(*endpoint)
+	resp, err := shared.Client.R().SetContext(ctx).SetResult(&health).Get(*endpoint)
 	if err != nil {
 		return nil, err
 	}
 
 	if resp.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to get cluster health: %s", resp.Status())
+		return nil, fmt.Errorf("failed to get cluster health: %w", client.NewResponseError(resp))
 	}
 
 	return &health, nil
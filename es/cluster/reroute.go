@@ -1,16 +1,68 @@
 package cluster
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
+	"github.com/pincher95/esctl/internal/client"
 	"github.com/pincher95/esctl/shared"
 )
 
 type Reroute struct {
-	Acknowledged bool            `json:"acknowledged"`
-	State        json.RawMessage `json:"state"`
-	Explanations json.RawMessage `json:"explanations"`
+	Acknowledged bool                 `json:"acknowledged"`
+	State        json.RawMessage      `json:"state,omitempty"`
+	Explanations []RerouteExplanation `json:"explanations,omitempty"`
+}
+
+// RerouteExplanation is the outcome of one reroute command, with the
+// decision of every allocation decider on it.
+type RerouteExplanation struct {
+	Command    string                 `json:"command"`
+	Parameters map[string]interface{} `json:"parameters"`
+	Decisions  []RerouteDecision      `json:"decisions"`
+}
+
+type RerouteDecision struct {
+	Decider     string `json:"decider"`
+	Decision    string `json:"decision"`
+	Explanation string `json:"explanation"`
+}
+
+// Reroute commands, named as in the cluster reroute API.
+const (
+	RerouteMove                 = "move"
+	RerouteCancel               = "cancel"
+	RerouteAllocateReplica      = "allocate_replica"
+	RerouteAllocateStalePrimary = "allocate_stale_primary"
+)
+
+// RerouteCommand is an entry of the commands array of a reroute request,
+// e.g. {"move": {"index": "logs", "shard": 0, "from_node": "n1", "to_node": "n2"}}.
+type RerouteCommand struct {
+	Name           string `json:"-"`
+	Index          string `json:"index"`
+	Shard          int    `json:"shard"`
+	FromNode       string `json:"from_node,omitempty"`
+	ToNode         string `json:"to_node,omitempty"`
+	Node           string `json:"node,omitempty"`
+	AllowPrimary   bool   `json:"allow_primary,omitempty"`
+	AcceptDataLoss bool   `json:"accept_data_loss,omitempty"`
+}
+
+func (c RerouteCommand) MarshalJSON() ([]byte, error) {
+	type parameters RerouteCommand
+	return json.Marshal(map[string]parameters{c.Name: parameters(c)})
+}
+
+// Allowed reports whether no decider rejected the command.
+func (e RerouteExplanation) Allowed() bool {
+	for _, decision := range e.Decisions {
+		if decision.Decision == "NO" {
+			return false
+		}
+	}
+	return true
 }
 
 // ClusterRerouteState is a sub type of ClusterRerouteResp containing information about the cluster and cluster routing
@@ -18,8 +70,8 @@ type ClusterRerouteState struct {
 	ClusterUUID        string                       `json:"cluster_uuid"`
 	Version            int                          `json:"version"`
 	StateUUID          string                       `json:"state_uuid"`
-	MasterNode         string                       `json:"master_node"`
-	ClusterManagerNode string                       `json:"cluster_manager_node"`
+	MasterNode         string                       `json:"master_node,omitempty"`
+	ClusterManagerNode string                       `json:"cluster_manager_node,omitempty"`
 	Blocks             json.RawMessage              `json:"blocks"`
 	Nodes              map[string]ClusterStateNodes `json:"nodes"`
 	RoutingTable       struct {
@@ -80,7 +132,9 @@ type ClusterStateRoutingNodes struct {
 	Nodes      map[string][]ClusterStateRoutingIndex `json:"nodes"`
 }
 
-func ClusterReroute(endpoint, flagMertic *string, dryRun, explain, retryFailed bool) (*Reroute, error) {
+// ClusterReroute runs the reroute commands, if any, and then tries to
+// allocate unassigned shards. A dry run only evaluates the commands.
+func ClusterReroute(ctx context.Context, endpoint, flagMertic *string, dryRun, explain, retryFailed bool, commands []RerouteCommand) (*Reroute, error) {
 	if endpoint == nil {
 		endpoint = new(string)
 		*endpoint = "_cluster/reroute?format=json"
@@ -104,13 +158,18 @@ func ClusterReroute(endpoint, flagMertic *string, dryRun, explain, retryFailed b
 
 	var reroute Reroute
 
-	resp, err := shared.Client.R().SetHeader("Content-Type", "application/json").SetResult(&reroute).Post(*endpoint)
+	req := shared.Client.R().SetContext(ctx).SetResult(&reroute)
+	if len(commands) > 0 {
+		req.SetBody(map[string]interface{}{"commands": commands})
+	}
+
+	resp, err := req.Post(*endpoint)
 	if err != nil {
 		return nil, err
 	}
 
 	if resp.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to post cluster reroute: %s", resp.Status())
+		return nil, fmt.Errorf("failed to post cluster reroute: %w", client.NewResponseError(resp))
 	}
 
 	return &reroute, nil
@@ -0,0 +1,58 @@
+package cluster
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRerouteCommandMarshalJSON(t *testing.T) {
+	tests := []struct {
+		command RerouteCommand
+		want    string
+	}{
+		{
+			RerouteCommand{Name: RerouteMove, Index: "logs", Shard: 0, FromNode: "n1", ToNode: "n2"},
+			`{"move":{"index":"logs","shard":0,"from_node":"n1","to_node":"n2"}}`,
+		},
+		{
+			RerouteCommand{Name: RerouteCancel, Index: "logs", Shard: 1, Node: "n1", AllowPrimary: true},
+			`{"cancel":{"index":"logs","shard":1,"node":"n1","allow_primary":true}}`,
+		},
+		{
+			RerouteCommand{Name: RerouteAllocateStalePrimary, Index: "logs", Shard: 2, Node: "n1", AcceptDataLoss: true},
+			`{"allocate_stale_primary":{"index":"logs","shard":2,"node":"n1","accept_data_loss":true}}`,
+		},
+	}
+
+	for _, test := range tests {
+		got, err := json.Marshal(test.command)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != test.want {
+			t.Errorf("got %s, want %s", got, test.want)
+		}
+	}
+}
+
+func TestRerouteExplanationAllowed(t *testing.T) {
+	var reroute Reroute
+	body := `{"acknowledged":true,"explanations":[{"command":"move","parameters":{"index":"logs"},"decisions":[
+		{"decider":"same_shard","decision":"YES","explanation":"the shard does not exist on the same node"},
+		{"decider":"disk_threshold","decision":"NO","explanation":"the node is above the high watermark"}]}]}`
+	if err := json.Unmarshal([]byte(body), &reroute); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(reroute.Explanations) != 1 || len(reroute.Explanations[0].Decisions) != 2 {
+		t.Fatalf("unexpected explanations %+v", reroute.Explanations)
+	}
+	if reroute.Explanations[0].Allowed() {
+		t.Error("expected the move to be rejected by the disk threshold decider")
+	}
+
+	reroute.Explanations[0].Decisions = reroute.Explanations[0].Decisions[:1]
+	if !reroute.Explanations[0].Allowed() {
+		t.Error("expected the move to be allowed")
+	}
+}
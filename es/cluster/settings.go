@@ -1,14 +1,16 @@
 package cluster
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/pincher95/esctl/internal/client"
 	"github.com/pincher95/esctl/shared"
 )
 
 type Settings map[string]any
 
-func ClusterSettings(endpoint *string, flat, defaults bool) (*Settings, error) {
+func ClusterSettings(ctx context.Context, endpoint *string, flat, defaults bool) (*Settings, error) {
 	if endpoint == nil {
 		endpoint = new(string)
 		*endpoint = "_cluster/settings?format=json"
@@ -24,13 +26,13 @@ func ClusterSettings(endpoint *string, flat, defaults bool) (*Settings, error) {
 
 	var settings Settings
 
-	resp, err := shared.Client.R().SetHeader("Content-Type", "application/json").SetResult(&settings).Get(*endpoint)
+	resp, err := shared.Client.R().SetContext(ctx).SetResult(&settings).Get(*endpoint)
 	if err != nil {
 		return nil, err
 	}
 
 	if resp.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to get cluster settings: %s", resp.Status())
+		return nil, fmt.Errorf("failed to get cluster settings: %w", client.NewResponseError(resp))
 	}
 
 	return &settings, nil
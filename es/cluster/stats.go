@@ -1,9 +1,11 @@
 package cluster
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
+	"github.com/pincher95/esctl/internal/client"
 	"github.com/pincher95/esctl/shared"
 )
 
@@ -99,7 +101,8 @@ type ClusterStatsIndices struct {
 		VersionMapMemoryInBytes   int   `json:"version_map_memory_in_bytes"`
 		FixedBitSetMemoryInBytes  int64 `json:"fixed_bit_set_memory_in_bytes"`
 		MaxUnsafeAutoIDTimestamp  int64 `json:"max_unsafe_auto_id_timestamp"`
-		RemoteStore               struct {
+		// RemoteStore and SegmentReplication are only reported by OpenSearch
+		RemoteStore *struct {
 			Upload struct {
 				TotalUploadSize struct {
 					StartedBytes   int `json:"started_bytes"`
@@ -124,13 +127,13 @@ type ClusterStatsIndices struct {
 				} `json:"total_download_size"`
 				TotalTimeSpentInMillis int `json:"total_time_spent_in_millis"`
 			} `json:"download"`
-		} `json:"remote_store"`
-		SegmentReplication struct {
+		} `json:"remote_store,omitempty"`
+		SegmentReplication *struct {
 			// Type is json.RawMessage due to difference in opensearch versions from string to int
 			MaxBytesBehind    json.RawMessage `json:"max_bytes_behind"`
 			TotalBytesBehind  json.RawMessage `json:"total_bytes_behind"`
 			MaxReplicationLag json.RawMessage `json:"max_replication_lag"`
-		} `json:"segment_replication"`
+		} `json:"segment_replication,omitempty"`
 		FileSizes json.RawMessage `json:"file_sizes"`
 	} `json:"segments"`
 	Mappings struct {
@@ -159,13 +162,14 @@ type ClusterStatsIndices struct {
 type ClusterStatsNodes struct {
 	Count struct {
 		Total               int `json:"total"`
-		ClusterManager      int `json:"cluster_manager"`
 		CoordinatingOnly    int `json:"coordinating_only"`
 		Data                int `json:"data"`
 		Ingest              int `json:"ingest"`
-		Master              int `json:"master"`
 		RemoteClusterClient int `json:"remote_cluster_client"`
-		Search              int `json:"search"`
+		// OpenSearch 2 counts cluster_manager nodes next to master, and search nodes
+		ClusterManager *int `json:"cluster_manager,omitempty"`
+		Master         *int `json:"master,omitempty"`
+		Search         *int `json:"search,omitempty"`
 	} `json:"count"`
 	Versions []string `json:"versions"`
 	Os       struct {
@@ -247,7 +251,7 @@ type ClusterStatsNodes struct {
 	} `json:"ingest"`
 }
 
-func ClusterStats(endpoint, node_id *string, remotes bool) (*Stats, error) {
+func ClusterStats(ctx context.Context, endpoint, node_id *string, remotes bool) (*Stats, error) {
 	if endpoint == nil {
 		endpoint = new(string)
 		*endpoint = "_cluster/stats?format=json"
@@ -263,13 +267,13 @@ func ClusterStats(endpoint, node_id *string, remotes bool) (*Stats, error) {
 
 	var stats Stats
 
-	resp, err := shared.Client.R().SetHeader("Content-Type", "application/json").SetResult(&stats).Get(*endpoint)
+	resp, err := shared.Client.R().SetContext(ctx).SetResult(&stats).Get(*endpoint)
 	if err != nil {
 		return nil, err
 	}
 
 	if resp.StatusCode() != 200 {
-		return nil, fmt.Errorf("failed to get cluster stats: %s", resp.Status())
+		return nil, fmt.Errorf("failed to get cluster stats: %w", client.NewResponseError(resp))
 	}
 
 	return &stats, nil
@@ -0,0 +1,221 @@
+// Package distribution detects whether the cluster is Elasticsearch or
+// OpenSearch and which version it runs, so commands can pick endpoints,
+// _cat columns and terminology that exist on it.
+package distribution
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pincher95/esctl/shared"
+)
+
+type Distribution string
+
+const (
+	Elasticsearch Distribution = "elasticsearch"
+	OpenSearch    Distribution = "opensearch"
+)
+
+// cacheTTL is how long a detected distribution is trusted, clusters are
+// rarely upgraded more often.
+const cacheTTL = 24 * time.Hour
+
+// Info is the distribution and version of a cluster. The zero value means
+// detection failed, e.g. because GET / is not permitted, and commands fall
+// back to their Elasticsearch defaults.
+type Info struct {
+	Distribution Distribution `json:"distribution"`
+	Version      string       `json:"version"`
+}
+
+func (i Info) IsOpenSearch() bool {
+	return i.Distribution == OpenSearch
+}
+
+func (i Info) IsElasticsearch() bool {
+	return i.Distribution == Elasticsearch
+}
+
+// AtLeast reports whether the version is major.minor or later, false when
+// the version is unknown.
+func (i Info) AtLeast(major, minor int) bool {
+	parts := strings.SplitN(i.Version, ".", 3)
+	if len(parts) < 2 {
+		return false
+	}
+	gotMajor, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	gotMinor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+	return gotMajor > major || gotMajor == major && gotMinor >= minor
+}
+
+// UsesClusterManager reports whether the cluster calls the master node the
+// cluster manager, as OpenSearch does since 2.0.
+func (i Info) UsesClusterManager() bool {
+	return i.IsOpenSearch() && i.AtLeast(2, 0)
+}
+
+func (i Info) String() string {
+	switch i.Distribution {
+	case OpenSearch:
+		return "OpenSearch " + i.Version
+	case Elasticsearch:
+		return "Elasticsearch " + i.Version
+	default:
+		return "unknown distribution"
+	}
+}
+
+type rootResponse struct {
+	Version struct {
+		Number       string `json:"number"`
+		Distribution string `json:"distribution"`
+	} `json:"version"`
+}
+
+// parseRoot reads the distribution from the response of GET /. Only
+// OpenSearch names itself, in version.distribution.
+func parseRoot(body []byte) (Info, error) {
+	var root rootResponse
+	if err := json.Unmarshal(body, &root); err != nil {
+		return Info{}, err
+	}
+	if root.Version.Number == "" {
+		return Info{}, fmt.Errorf("no version in response")
+	}
+
+	info := Info{Distribution: Elasticsearch, Version: root.Version.Number}
+	if strings.EqualFold(root.Version.Distribution, string(OpenSearch)) {
+		info.Distribution = OpenSearch
+	}
+	return info, nil
+}
+
+var (
+	mu       sync.Mutex
+	detected *Info
+)
+
+// Detect returns the distribution of the cluster of the current context. It
+// probes GET / at most once per run and caches the result on disk per
+// cluster, so most commands do not pay for the extra request. A failed probe
+// is only remembered for the run, so e.g. a watch does not probe again on
+// every refresh.
+func Detect(ctx context.Context) Info {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if detected != nil {
+		return *detected
+	}
+
+	key := cacheKey()
+	path := cachePath()
+	if info, ok := readCache(path, key, time.Now()); ok {
+		detected = &info
+		return info
+	}
+
+	info, err := probe(ctx)
+	detected = &info
+	if err != nil {
+		// The command reports the error of its own request, if any
+		if shared.Debug {
+			fmt.Fprintf(os.Stderr, "DEBUG: failed to detect the distribution, using Elasticsearch defaults: %v\n", err)
+		}
+		return info
+	}
+	writeCache(path, key, info, time.Now())
+	return info
+}
+
+func probe(ctx context.Context) (Info, error) {
+	resp, err := shared.Client.R().SetContext(ctx).Get("/")
+	if err != nil {
+		return Info{}, err
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return Info{}, fmt.Errorf("GET / returned %s", resp.Status())
+	}
+	return parseRoot(resp.Body())
+}
+
+// cacheKey identifies the cluster of the current context by its URL, so
+// contexts for the same cluster share the cache entry.
+func cacheKey() string {
+	return shared.Client.BaseURL
+}
+
+func cachePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "esctl", "distribution.json")
+}
+
+type cacheEntry struct {
+	Info
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+func readCache(path, key string, now time.Time) (Info, bool) {
+	if path == "" {
+		return Info{}, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Info{}, false
+	}
+
+	var entries map[string]cacheEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return Info{}, false
+	}
+	entry, ok := entries[key]
+	if !ok || now.Sub(entry.DetectedAt) > cacheTTL {
+		return Info{}, false
+	}
+	return entry.Info, true
+}
+
+// writeCache stores info for key, a failure only costs another probe.
+func writeCache(path, key string, info Info, now time.Time) {
+	if path == "" {
+		return
+	}
+
+	entries := make(map[string]cacheEntry)
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &entries)
+	}
+	for k, entry := range entries {
+		if now.Sub(entry.DetectedAt) > cacheTTL {
+			delete(entries, k)
+		}
+	}
+	entries[key] = cacheEntry{Info: info, DetectedAt: now}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0o600)
+}
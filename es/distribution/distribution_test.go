@@ -0,0 +1,112 @@
+package distribution
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pincher95/esctl/internal/client"
+	"github.com/pincher95/esctl/shared"
+)
+
+func TestParseRoot(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want Info
+	}{
+		{"elasticsearch 8", `{"version":{"number":"8.11.1","build_flavor":"default"}}`, Info{Elasticsearch, "8.11.1"}},
+		{"elasticsearch 7", `{"version":{"number":"7.17.9","build_flavor":"default"}}`, Info{Elasticsearch, "7.17.9"}},
+		{"opensearch 2", `{"version":{"distribution":"opensearch","number":"2.11.0"}}`, Info{OpenSearch, "2.11.0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRoot([]byte(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := parseRoot([]byte(`{"error":"forbidden"}`)); err == nil {
+		t.Error("expected an error for a response without a version")
+	}
+}
+
+func TestAtLeast(t *testing.T) {
+	tests := []struct {
+		version      string
+		major, minor int
+		want         bool
+	}{
+		{"2.11.0", 2, 0, true},
+		{"2.11.0", 2, 12, false},
+		{"8.0.0", 7, 17, true},
+		{"7.17.9", 8, 0, false},
+		{"", 1, 0, false},
+	}
+	for _, tt := range tests {
+		if got := (Info{Version: tt.version}).AtLeast(tt.major, tt.minor); got != tt.want {
+			t.Errorf("%q.AtLeast(%d, %d) = %v, want %v", tt.version, tt.major, tt.minor, got, tt.want)
+		}
+	}
+}
+
+func TestCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "esctl", "distribution.json")
+	now := time.Now()
+	info := Info{OpenSearch, "2.11.0"}
+
+	if _, ok := readCache(path, "http://a:9200", now); ok {
+		t.Fatal("expected a miss without a cache file")
+	}
+
+	writeCache(path, "http://a:9200", info, now)
+	if got, ok := readCache(path, "http://a:9200", now.Add(time.Hour)); !ok || got != info {
+		t.Errorf("got %+v, %v, want %+v", got, ok, info)
+	}
+	if _, ok := readCache(path, "http://b:9200", now); ok {
+		t.Error("expected a miss for another cluster")
+	}
+	if _, ok := readCache(path, "http://a:9200", now.Add(cacheTTL+time.Minute)); ok {
+		t.Error("expected a miss once the entry expired")
+	}
+}
+
+func TestDetectRemembersFailedProbe(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	c, err := client.NewClient(&client.Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous := shared.Client
+	shared.Client = c
+	detected = nil
+	defer func() {
+		shared.Client = previous
+		detected = nil
+	}()
+
+	for i := 0; i < 3; i++ {
+		if info := Detect(context.Background()); info != (Info{}) {
+			t.Errorf("got %+v, want the zero value", info)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("got %d requests, want 1", requests)
+	}
+}
@@ -1,10 +1,15 @@
 package es
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/url"
+	"sort"
 	"strings"
 
 	"github.com/pincher95/esctl/es/cat"
+	"github.com/pincher95/esctl/internal/client"
 )
 
 type IndexMappings struct {
@@ -25,20 +30,20 @@ type IndexDetails struct {
 
 type IndexDetailsResponse map[string]IndexDetails
 
-func GetIndexDetails(index string, shouldGetMappings, shouldGetSettings bool) (IndexDetailsResponse, error) {
+func GetIndexDetails(ctx context.Context, index string, shouldGetMappings, shouldGetSettings bool) (IndexDetailsResponse, error) {
 	var mappingsResponse MappingsResponse
 	var settingsResponse SettingsResponse
 
 	if shouldGetMappings {
 		mappingsEndpoint := fmt.Sprintf("%s/_mappings", index)
-		if err := getJSONResponse(mappingsEndpoint, &mappingsResponse); err != nil {
+		if err := getJSONResponse(ctx, mappingsEndpoint, &mappingsResponse); err != nil {
 			return nil, fmt.Errorf("failed to get index mappings: %w", err)
 		}
 	}
 
 	if shouldGetSettings {
 		settingsEndpoint := fmt.Sprintf("%s/_settings", index)
-		if err := getJSONResponse(settingsEndpoint, &settingsResponse); err != nil {
+		if err := getJSONResponse(ctx, settingsEndpoint, &settingsResponse); err != nil {
 			return nil, fmt.Errorf("failed to get index settings: %w", err)
 		}
 	}
@@ -69,19 +74,290 @@ func GetIndexDetails(index string, shouldGetMappings, shouldGetSettings bool) (I
 	return merged, nil
 }
 
+// IndexTemplate is a composable index template, applied to new indices whose
+// name matches one of its patterns. When several templates match, the one
+// with the highest priority wins.
+type IndexTemplate struct {
+	Name          string                 `json:"name"`
+	IndexPatterns []string               `json:"index_patterns"`
+	Priority      int                    `json:"priority"`
+	Version       int                    `json:"version,omitempty"`
+	ComposedOf    []string               `json:"composed_of"`
+	DataStream    bool                   `json:"data_stream"`
+	Meta          map[string]interface{} `json:"_meta,omitempty"`
+	Template      TemplateBody           `json:"template"`
+}
+
+// ComponentTemplate is a reusable block of settings, mappings and aliases
+// that index templates are composed of. UsedBy lists those index templates.
+type ComponentTemplate struct {
+	Name     string                 `json:"name"`
+	Version  int                    `json:"version,omitempty"`
+	Meta     map[string]interface{} `json:"_meta,omitempty"`
+	Template TemplateBody           `json:"template"`
+	UsedBy   []string               `json:"used_by"`
+}
+
+// TemplateBody is what a template applies to a new index.
+type TemplateBody struct {
+	Settings interface{} `json:"settings,omitempty"`
+	Mappings interface{} `json:"mappings,omitempty"`
+	Aliases  interface{} `json:"aliases,omitempty"`
+}
+
+// TemplateOverlap is another index template matching some of the same
+// indices, which loses to the described template on priority.
+type TemplateOverlap struct {
+	Name          string   `json:"name"`
+	IndexPatterns []string `json:"index_patterns"`
+}
+
+// IndexTemplateDescription is an index template together with the settings,
+// mappings and aliases it resolves to once merged with its component
+// templates.
+type IndexTemplateDescription struct {
+	IndexTemplate
+	Resolved    TemplateBody      `json:"resolved"`
+	Overlapping []TemplateOverlap `json:"overlapping"`
+}
+
+// IndexTemplateMatch is what a new index would be created with: the
+// composable index template it matches, if any, the settings, mappings and
+// aliases it resolves to, and the templates overlapping with it.
+type IndexTemplateMatch struct {
+	Index       string            `json:"index"`
+	Template    *IndexTemplate    `json:"template"`
+	Resolved    *TemplateBody     `json:"resolved,omitempty"`
+	Overlapping []TemplateOverlap `json:"overlapping"`
+}
+
+type indexTemplatesResponse struct {
+	IndexTemplates []struct {
+		Name          string `json:"name"`
+		IndexTemplate struct {
+			IndexPatterns []string               `json:"index_patterns"`
+			Priority      int                    `json:"priority"`
+			Version       int                    `json:"version"`
+			ComposedOf    []string               `json:"composed_of"`
+			DataStream    map[string]interface{} `json:"data_stream"`
+			Meta          map[string]interface{} `json:"_meta"`
+			Template      TemplateBody           `json:"template"`
+		} `json:"index_template"`
+	} `json:"index_templates"`
+}
+
+type componentTemplatesResponse struct {
+	ComponentTemplates []struct {
+		Name              string `json:"name"`
+		ComponentTemplate struct {
+			Version  int                    `json:"version"`
+			Meta     map[string]interface{} `json:"_meta"`
+			Template TemplateBody           `json:"template"`
+		} `json:"component_template"`
+	} `json:"component_templates"`
+}
+
+type simulateTemplateResponse struct {
+	Template    TemplateBody      `json:"template"`
+	Overlapping []TemplateOverlap `json:"overlapping"`
+}
+
+// simulateIndexResponse is empty when no composable index template matches.
+type simulateIndexResponse struct {
+	Template    *TemplateBody     `json:"template"`
+	Overlapping []TemplateOverlap `json:"overlapping"`
+}
+
+// Description is the description in the template's _meta, if any.
+func (t IndexTemplate) Description() string {
+	return metaDescription(t.Meta)
+}
+
+// Description is the description in the template's _meta, if any.
+func (t ComponentTemplate) Description() string {
+	return metaDescription(t.Meta)
+}
+
+func metaDescription(meta map[string]interface{}) string {
+	description, _ := meta["description"].(string)
+	return description
+}
+
+// GetIndexTemplates lists the composable index templates matching name, or
+// all of them when it is empty, sorted by name.
+func GetIndexTemplates(ctx context.Context, name string) ([]IndexTemplate, error) {
+	endpoint := "_index_template"
+	if name != "" {
+		endpoint += "/" + name
+	}
+
+	var response indexTemplatesResponse
+	if err := getJSONResponse(ctx, endpoint, &response); err != nil {
+		return nil, err
+	}
+
+	templates := make([]IndexTemplate, 0, len(response.IndexTemplates))
+	for _, t := range response.IndexTemplates {
+		composedOf := t.IndexTemplate.ComposedOf
+		if composedOf == nil {
+			composedOf = []string{}
+		}
+		templates = append(templates, IndexTemplate{
+			Name:          t.Name,
+			IndexPatterns: t.IndexTemplate.IndexPatterns,
+			Priority:      t.IndexTemplate.Priority,
+			Version:       t.IndexTemplate.Version,
+			ComposedOf:    composedOf,
+			DataStream:    t.IndexTemplate.DataStream != nil,
+			Meta:          t.IndexTemplate.Meta,
+			Template:      t.IndexTemplate.Template,
+		})
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates, nil
+}
+
+// GetComponentTemplates lists the component templates matching name, or all
+// of them when it is empty, sorted by name, with the index templates
+// composed of each.
+func GetComponentTemplates(ctx context.Context, name string) ([]ComponentTemplate, error) {
+	endpoint := "_component_template"
+	if name != "" {
+		endpoint += "/" + name
+	}
+
+	var response componentTemplatesResponse
+	if err := getJSONResponse(ctx, endpoint, &response); err != nil {
+		return nil, err
+	}
+
+	indexTemplates, err := GetIndexTemplates(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	usedBy := make(map[string][]string)
+	for _, t := range indexTemplates {
+		for _, component := range t.ComposedOf {
+			usedBy[component] = append(usedBy[component], t.Name)
+		}
+	}
+
+	templates := make([]ComponentTemplate, 0, len(response.ComponentTemplates))
+	for _, t := range response.ComponentTemplates {
+		templates = append(templates, ComponentTemplate{
+			Name:     t.Name,
+			Version:  t.ComponentTemplate.Version,
+			Meta:     t.ComponentTemplate.Meta,
+			Template: t.ComponentTemplate.Template,
+			UsedBy:   append([]string{}, usedBy[t.Name]...),
+		})
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates, nil
+}
+
+// DescribeIndexTemplate returns an index template with the settings, mappings
+// and aliases it resolves to, as simulated by _index_template/_simulate.
+func DescribeIndexTemplate(ctx context.Context, name string) (*IndexTemplateDescription, error) {
+	templates, err := GetIndexTemplates(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	description := &IndexTemplateDescription{}
+	found := false
+	for _, t := range templates {
+		if t.Name == name {
+			description.IndexTemplate = t
+			found = true
+		}
+	}
+	if !found {
+		return nil, &client.NotFoundError{Kind: "index template", Name: name}
+	}
+
+	var response simulateTemplateResponse
+	if err := postWithoutBody(ctx, "_index_template/_simulate/"+url.PathEscape(name), &response); err != nil {
+		return nil, fmt.Errorf("failed to simulate index template: %w", err)
+	}
+	description.Resolved = response.Template
+	description.Overlapping = response.Overlapping
+	if description.Overlapping == nil {
+		description.Overlapping = []TemplateOverlap{}
+	}
+	return description, nil
+}
+
+// MatchIndexTemplate simulates creating an index with
+// _index_template/_simulate_index, which resolves the matching template with
+// its component templates and lists the templates overlapping with it. The
+// response does not name the matching template, so it is looked up as the
+// matching template with the highest priority, the way Elasticsearch picks it.
+func MatchIndexTemplate(ctx context.Context, index string) (*IndexTemplateMatch, error) {
+	var response simulateIndexResponse
+	if err := postWithoutBody(ctx, "_index_template/_simulate_index/"+url.PathEscape(index), &response); err != nil {
+		return nil, err
+	}
+
+	match := &IndexTemplateMatch{Index: index, Resolved: response.Template, Overlapping: response.Overlapping}
+	if match.Overlapping == nil {
+		match.Overlapping = []TemplateOverlap{}
+	}
+	if response.Template == nil {
+		return match, nil
+	}
+
+	templates, err := GetIndexTemplates(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	match.Template = matchingTemplate(index, templates)
+	return match, nil
+}
+
+// matchingTemplate returns the template with the highest priority among those
+// with a pattern matching index, or nil.
+func matchingTemplate(index string, templates []IndexTemplate) *IndexTemplate {
+	var best *IndexTemplate
+	for i := range templates {
+		if !matchTemplatePatterns(index, templates[i].IndexPatterns) {
+			continue
+		}
+		if best == nil || templates[i].Priority > best.Priority {
+			best = &templates[i]
+		}
+	}
+	return best
+}
+
+// matchTemplatePatterns reports whether index matches one of the index
+// patterns of a template, which only know the '*' wildcard.
+func matchTemplatePatterns(index string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if simpleMatch(pattern, index) {
+			return true
+		}
+	}
+	return false
+}
+
 type AliasResponse map[string]AliasDetail
 
 type AliasDetail struct {
 	Aliases map[string]interface{} `json:"aliases"`
 }
 
-func GetAliases(index string) (map[string]string, error) {
+func GetAliases(ctx context.Context, index string) (map[string]string, error) {
 	if index == "" {
 		index = "_all"
 	}
 
 	var aliasResp AliasResponse
-	if err := getJSONResponse(index+"/_alias", &aliasResp); err != nil {
+	if err := getJSONResponse(ctx, index+"/_alias", &aliasResp); err != nil {
 		return nil, err
 	}
 
@@ -95,6 +371,142 @@ func GetAliases(index string) (map[string]string, error) {
 	return aliases, nil
 }
 
+// IndexDescription is everything 'describe index' shows about one index.
+type IndexDescription struct {
+	Name             string          `json:"name"`
+	UUID             string          `json:"uuid"`
+	Health           string          `json:"health"`
+	Status           string          `json:"status"`
+	CreationDate     string          `json:"creation_date,omitempty"`
+	Primaries        int             `json:"primaries"`
+	Replicas         int             `json:"replicas"`
+	DocsCount        int             `json:"docs_count"`
+	DocsDeleted      int             `json:"docs_deleted"`
+	StoreSize        string          `json:"store_size,omitempty"`
+	PrimaryStoreSize string          `json:"primary_store_size,omitempty"`
+	Aliases          []string        `json:"aliases"`
+	Lifecycle        *IndexLifecycle `json:"ilm,omitempty"`
+	Shards           []IndexShard    `json:"shards"`
+	Settings         interface{}     `json:"settings,omitempty"`
+	Mappings         interface{}     `json:"mappings,omitempty"`
+}
+
+// IndexShard is a shard copy of an index and the node it is allocated to.
+type IndexShard struct {
+	Shard            int    `json:"shard"`
+	Primary          bool   `json:"primary"`
+	State            string `json:"state"`
+	Node             string `json:"node,omitempty"`
+	Docs             string `json:"docs,omitempty"`
+	Store            string `json:"store,omitempty"`
+	UnassignedReason string `json:"unassigned_reason,omitempty"`
+}
+
+// DescribeIndices gathers health, document counts, sizes, aliases, ILM state
+// and shard placement of the indices matching index, sorted by name. Mappings
+// and settings are only fetched when asked for.
+func DescribeIndices(ctx context.Context, index string, withMappings, withSettings bool) ([]IndexDescription, error) {
+	indices, err := cat.CatIndices(ctx, nil, &index, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(indices) == 0 {
+		return nil, &client.NotFoundError{Kind: "index", Name: index}
+	}
+
+	var aliasResp AliasResponse
+	if err := getJSONResponse(ctx, joinIndexNames(strings.Split(index, ","))+"/_alias", &aliasResp); err != nil {
+		return nil, fmt.Errorf("failed to get aliases: %w", err)
+	}
+
+	shards, err := cat.CatShards(ctx, nil, &index, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// ILM may not be permitted, and ISM is a plugin on OpenSearch, so it is optional
+	lifecycles := make(map[string]IndexLifecycle)
+	explained, err := ExplainLifecycle(ctx, index, false, false)
+	if err != nil {
+		var respErr *client.ResponseError
+		if !errors.As(err, &respErr) {
+			return nil, fmt.Errorf("failed to get lifecycle state: %w", err)
+		}
+	}
+	for _, lifecycle := range explained {
+		lifecycles[lifecycle.Index] = lifecycle
+	}
+
+	var details IndexDetailsResponse
+	if withMappings || withSettings {
+		if details, err = GetIndexDetails(ctx, index, withMappings, withSettings); err != nil {
+			return nil, err
+		}
+	}
+
+	descriptions := make([]IndexDescription, 0, len(indices))
+	for _, idx := range indices {
+		description := IndexDescription{
+			Name:             idx.Index,
+			UUID:             idx.UUID,
+			Health:           idx.Health,
+			Status:           idx.Status,
+			CreationDate:     idx.CreationDateString,
+			Primaries:        intValue(idx.Primary),
+			Replicas:         intValue(idx.Replica),
+			DocsCount:        intValue(idx.DocsCount),
+			DocsDeleted:      intValue(idx.DocDeleted),
+			StoreSize:        stringValue(idx.StoreSize),
+			PrimaryStoreSize: stringValue(idx.PrimaryStoreSize),
+			Aliases:          []string{},
+			Shards:           []IndexShard{},
+			Settings:         details[idx.Index].Settings,
+			Mappings:         details[idx.Index].Mappings,
+		}
+
+		for alias := range aliasResp[idx.Index].Aliases {
+			description.Aliases = append(description.Aliases, alias)
+		}
+		sort.Strings(description.Aliases)
+
+		if state, ok := lifecycles[idx.Index]; ok {
+			description.Lifecycle = &state
+		}
+
+		for _, shard := range shards {
+			if shard.Index != idx.Index {
+				continue
+			}
+			// A relocating shard is listed as 'source -> ip id target', keep the source
+			node, _, _ := strings.Cut(stringValue(shard.Node), " -> ")
+			description.Shards = append(description.Shards, IndexShard{
+				Shard:            shard.Shard,
+				Primary:          shard.Prirep == "p",
+				State:            shard.State,
+				Node:             node,
+				Docs:             stringValue(shard.Docs),
+				Store:            stringValue(shard.Store),
+				UnassignedReason: stringValue(shard.UnassignedReason),
+			})
+		}
+		sort.SliceStable(description.Shards, func(i, j int) bool {
+			a, b := description.Shards[i], description.Shards[j]
+			if a.Shard != b.Shard {
+				return a.Shard < b.Shard
+			}
+			return a.Primary && !b.Primary
+		})
+
+		descriptions = append(descriptions, description)
+	}
+
+	sort.Slice(descriptions, func(i, j int) bool {
+		return descriptions[i].Name < descriptions[j].Name
+	})
+
+	return descriptions, nil
+}
+
 type CountResponse struct {
 	Count        int                    `json:"count"`
 	Aggregations map[string]interface{} `json:"aggregations,omitempty"`
@@ -162,7 +574,7 @@ func buildFilterQueries(termFilters, existsFilters []string, nestedPaths []strin
 	return filterQueries
 }
 
-func countDocumentsOfIndex(index string, termFilters, existsFilters, nestedPaths []string) (int, error) {
+func countDocumentsOfIndex(ctx context.Context, index string, termFilters, existsFilters, nestedPaths []string) (int, error) {
 	endpoint := index + "/_count"
 	query := map[string]interface{}{
 		"match_all": map[string]interface{}{},
@@ -181,7 +593,7 @@ func countDocumentsOfIndex(index string, termFilters, existsFilters, nestedPaths
 	}
 
 	var response CountResponse
-	if err := postJSONResponseWithBody(endpoint, &response, body); err != nil {
+	if err := postJSONResponseWithBody(ctx, endpoint, &response, body); err != nil {
 		return 0, err
 	}
 
@@ -190,13 +602,14 @@ func countDocumentsOfIndex(index string, termFilters, existsFilters, nestedPaths
 
 type RefreshResponse map[string]interface{}
 
-func RefreshIndices(target string) error {
+func RefreshIndices(ctx context.Context, target string) error {
 	endpoint := target + "/_refresh"
 	var response RefreshResponse
-	return postWithoutBody(endpoint, &response)
+	return postWithoutBody(ctx, endpoint, &response)
 }
 
 func groupDocumentsOfIndex(
+	ctx context.Context,
 	index string,
 	termFilters []string,
 	existsFilters []string,
@@ -259,7 +672,7 @@ func groupDocumentsOfIndex(
 	}
 
 	var response CountResponse
-	if err := postJSONResponseWithBody(endpoint, &response, body); err != nil {
+	if err := postJSONResponseWithBody(ctx, endpoint, &response, body); err != nil {
 		return nil, err
 	}
 
@@ -288,6 +701,7 @@ func groupDocumentsOfIndex(
 }
 
 func CountDocuments(
+	ctx context.Context,
 	index string,
 	termFilters []string,
 	existsFilters []string,
@@ -298,13 +712,13 @@ func CountDocuments(
 	refresh bool,
 ) (map[string]GroupCount, error) {
 	if refresh {
-		err := RefreshIndices(index)
+		err := RefreshIndices(ctx, index)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	indices, err := cat.CatIndices(nil, &index, nil)
+	indices, err := cat.CatIndices(ctx, nil, &index, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -313,13 +727,13 @@ func CountDocuments(
 	for _, index := range indices {
 		var groupCount GroupCount
 		if groupBy == "" {
-			count, err := countDocumentsOfIndex(index.Index, termFilters, existsFilters, nestedPaths)
+			count, err := countDocumentsOfIndex(ctx, index.Index, termFilters, existsFilters, nestedPaths)
 			if err != nil {
 				return nil, err
 			}
 			groupCount = map[string]int{"": count}
 		} else {
-			groupCount, err = groupDocumentsOfIndex(index.Index, termFilters, existsFilters, nestedPaths, groupBy, size, timeout)
+			groupCount, err = groupDocumentsOfIndex(ctx, index.Index, termFilters, existsFilters, nestedPaths, groupBy, size, timeout)
 			if err != nil {
 				return nil, err
 			}
@@ -0,0 +1,75 @@
+package es
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/pincher95/esctl/internal/client"
+)
+
+func TestMatchIndexTemplate(t *testing.T) {
+	templates := `{"index_templates": [
+		{"name": "catchall", "index_template": {"index_patterns": ["*"]}},
+		{"name": "logs", "index_template": {"index_patterns": ["logs-*", "syslog-*"], "priority": 200}},
+		{"name": "logs-app", "index_template": {"index_patterns": ["logs-app-*"], "priority": 300}}
+	]}`
+
+	tests := []struct {
+		index       string
+		simulated   string
+		want        string
+		overlapping int
+	}{
+		{"logs-app-1", `{"template": {"settings": {}}, "overlapping": [{"name": "logs", "index_patterns": ["logs-*"]}]}`, "logs-app", 1},
+		{"syslog-1", `{"template": {"settings": {}}, "overlapping": []}`, "logs", 0},
+		{"metrics-1", `{}`, "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.index, func(t *testing.T) {
+			withServer(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/_index_template/_simulate_index/"+tt.index:
+					_, _ = w.Write([]byte(tt.simulated))
+				case r.Method == http.MethodGet && r.URL.Path == "/_index_template":
+					_, _ = w.Write([]byte(templates))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+
+			match, err := MatchIndexTemplate(context.Background(), tt.index)
+			if err != nil {
+				t.Fatal(err)
+			}
+			switch {
+			case tt.want == "" && (match.Template != nil || match.Resolved != nil):
+				t.Errorf("got template %+v, want none", match.Template)
+			case tt.want != "" && (match.Template == nil || match.Template.Name != tt.want || match.Resolved == nil):
+				t.Errorf("got template %+v, want %s", match.Template, tt.want)
+			}
+			if len(match.Overlapping) != tt.overlapping {
+				t.Errorf("got overlapping %+v, want %d", match.Overlapping, tt.overlapping)
+			}
+		})
+	}
+}
+
+func TestDescribeIndicesNotFound(t *testing.T) {
+	withServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_cat/indices/missing-*" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	})
+
+	_, err := DescribeIndices(context.Background(), "missing-*", false, false)
+	var notFound *client.NotFoundError
+	if !errors.As(err, &notFound) || notFound.Kind != "index" || notFound.Name != "missing-*" {
+		t.Errorf("got %v, want an index not found error", err)
+	}
+}
@@ -0,0 +1,259 @@
+package es
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/pincher95/esctl/es/cat"
+	"github.com/pincher95/esctl/es/distribution"
+	"github.com/pincher95/esctl/internal/client"
+)
+
+// IndexTarget is an index a write command is about to change, shown in the
+// preview before asking for confirmation.
+type IndexTarget struct {
+	Name      string `json:"name"`
+	Health    string `json:"health"`
+	Status    string `json:"status"`
+	Primaries int    `json:"primaries"`
+	Replicas  int    `json:"replicas"`
+	DocsCount int    `json:"docs_count"`
+	StoreSize string `json:"store_size,omitempty"`
+}
+
+// Acknowledgement is the response of index create, delete, open, close and
+// resize requests.
+type Acknowledgement struct {
+	Acknowledged       bool   `json:"acknowledged"`
+	ShardsAcknowledged bool   `json:"shards_acknowledged"`
+	Index              string `json:"index,omitempty"`
+}
+
+// RolloverResponse is the result of rolling an alias or data stream over to
+// a new index.
+type RolloverResponse struct {
+	Acknowledged       bool            `json:"acknowledged"`
+	ShardsAcknowledged bool            `json:"shards_acknowledged"`
+	OldIndex           string          `json:"old_index"`
+	NewIndex           string          `json:"new_index"`
+	RolledOver         bool            `json:"rolled_over"`
+	DryRun             bool            `json:"dry_run"`
+	Conditions         map[string]bool `json:"conditions"`
+}
+
+// ResizeOperation is the way a resize copies an index into a new one.
+type ResizeOperation string
+
+const (
+	ResizeClone  ResizeOperation = "clone"
+	ResizeShrink ResizeOperation = "shrink"
+	ResizeSplit  ResizeOperation = "split"
+)
+
+// ResolveIndices lists the indices matching pattern, sorted by name, so a
+// write command can act on exactly the indices it previewed. Wildcards are
+// resolved here because clusters may refuse destructive wildcard requests.
+// An empty pattern is refused, and one matching every index, e.g. '*' or
+// '_all', only with allowAll.
+func ResolveIndices(ctx context.Context, pattern string, allowAll bool) ([]IndexTarget, error) {
+	if strings.TrimSpace(pattern) == "" {
+		return nil, errors.New("an index name or pattern is required")
+	}
+	if matchesAllIndices(pattern) && !allowAll {
+		return nil, fmt.Errorf("%q matches every index, pass --all to confirm", pattern)
+	}
+
+	indices, err := cat.CatIndices(ctx, nil, &pattern, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(indices) == 0 {
+		return nil, &client.NotFoundError{Kind: "index", Name: pattern}
+	}
+
+	targets := make([]IndexTarget, 0, len(indices))
+	for _, idx := range indices {
+		targets = append(targets, IndexTarget{
+			Name:      idx.Index,
+			Health:    idx.Health,
+			Status:    idx.Status,
+			Primaries: intValue(idx.Primary),
+			Replicas:  intValue(idx.Replica),
+			DocsCount: intValue(idx.DocsCount),
+			StoreSize: stringValue(idx.StoreSize),
+		})
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].Name < targets[j].Name
+	})
+	return targets, nil
+}
+
+// matchesAllIndices reports whether one of the comma-separated patterns
+// matches every index.
+func matchesAllIndices(pattern string) bool {
+	for _, p := range strings.Split(pattern, ",") {
+		p = strings.TrimSpace(p)
+		if p == "_all" || p != "" && strings.Trim(p, "*") == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateIndex creates an index, body may hold its settings, mappings and aliases.
+func CreateIndex(ctx context.Context, name string, body map[string]interface{}) (*Acknowledgement, error) {
+	var req interface{}
+	if len(body) > 0 {
+		req = body
+	}
+
+	var response Acknowledgement
+	if err := putJSONResponseWithBody(ctx, url.PathEscape(name), &response, req); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func DeleteIndices(ctx context.Context, names []string) (*Acknowledgement, error) {
+	return acknowledgeBatches(names, func(indices string, response *Acknowledgement) error {
+		return deleteJSONResponse(ctx, indices, response)
+	})
+}
+
+func OpenIndices(ctx context.Context, names []string) (*Acknowledgement, error) {
+	return postIndicesAction(ctx, names, "_open")
+}
+
+func CloseIndices(ctx context.Context, names []string) (*Acknowledgement, error) {
+	return postIndicesAction(ctx, names, "_close")
+}
+
+// CheckFreezeSupported fails for clusters without frozen indices, which were
+// removed in Elasticsearch 8.0 and never existed in OpenSearch.
+func CheckFreezeSupported(ctx context.Context) error {
+	info := distribution.Detect(ctx)
+	if info.IsOpenSearch() || info.IsElasticsearch() && info.AtLeast(8, 0) {
+		return fmt.Errorf("frozen indices are not supported by %s", info)
+	}
+	return nil
+}
+
+// FreezeIndices makes indices read-only with a minimal memory footprint.
+func FreezeIndices(ctx context.Context, names []string) (*Acknowledgement, error) {
+	return postIndicesAction(ctx, names, "_freeze")
+}
+
+func UnfreezeIndices(ctx context.Context, names []string) (*Acknowledgement, error) {
+	return postIndicesAction(ctx, names, "_unfreeze")
+}
+
+func postIndicesAction(ctx context.Context, names []string, endpoint string) (*Acknowledgement, error) {
+	return acknowledgeBatches(names, func(indices string, response *Acknowledgement) error {
+		return postWithoutBody(ctx, indices+"/"+endpoint, response)
+	})
+}
+
+// acknowledgeBatches sends request for every batch of names, see
+// batchIndexNames. The result is only acknowledged when every batch was.
+func acknowledgeBatches(names []string, request func(indices string, response *Acknowledgement) error) (*Acknowledgement, error) {
+	acknowledgement := Acknowledgement{Acknowledged: true, ShardsAcknowledged: true}
+	done := 0
+	for _, batch := range batchIndexNames(names) {
+		var response Acknowledgement
+		if err := request(batch, &response); err != nil {
+			if done > 0 {
+				return nil, fmt.Errorf("failed after %d of %d indices: %w", done, len(names), err)
+			}
+			return nil, err
+		}
+		acknowledgement.Acknowledged = acknowledgement.Acknowledged && response.Acknowledged
+		acknowledgement.ShardsAcknowledged = acknowledgement.ShardsAcknowledged && response.ShardsAcknowledged
+		done += strings.Count(batch, ",") + 1
+	}
+	return &acknowledgement, nil
+}
+
+// ResizeIndex clones, shrinks or splits source into the new index target.
+// A shards count of 0 leaves the number of primary shards to Elasticsearch.
+func ResizeIndex(ctx context.Context, operation ResizeOperation, source, target string, shards int) (*Acknowledgement, error) {
+	var body interface{}
+	if shards > 0 {
+		body = map[string]interface{}{
+			"settings": map[string]interface{}{"index.number_of_shards": shards},
+		}
+	}
+
+	endpoint := fmt.Sprintf("%s/_%s/%s", url.PathEscape(source), operation, url.PathEscape(target))
+
+	var response Acknowledgement
+	if err := putJSONResponseWithBody(ctx, endpoint, &response, body); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// RolloverIndex rolls alias over to a new index when any of conditions is
+// met, or unconditionally without conditions. newIndex may be empty to let
+// Elasticsearch derive the name. A dry run only evaluates the conditions.
+func RolloverIndex(ctx context.Context, alias, newIndex string, conditions map[string]interface{}, dryRun bool) (*RolloverResponse, error) {
+	endpoint := url.PathEscape(alias) + "/_rollover"
+	if newIndex != "" {
+		endpoint += "/" + url.PathEscape(newIndex)
+	}
+	if dryRun {
+		endpoint += "?dry_run=true"
+	}
+
+	var body interface{}
+	if len(conditions) > 0 {
+		body = map[string]interface{}{"conditions": conditions}
+	}
+
+	var response RolloverResponse
+	if err := postJSONResponseWithBody(ctx, endpoint, &response, body); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// maxIndexNamesLength bounds the index names sent in one request path, well
+// below the 4kb http.max_initial_line_length of Elasticsearch.
+const maxIndexNamesLength = 3000
+
+// batchIndexNames joins resolved index names into as few path segments as
+// possible, escaping every name, while keeping each request line under the
+// length the cluster accepts.
+func batchIndexNames(names []string) []string {
+	var batches []string
+	var batch strings.Builder
+	for _, name := range names {
+		escaped := url.PathEscape(name)
+		if batch.Len() > 0 && batch.Len()+1+len(escaped) > maxIndexNamesLength {
+			batches = append(batches, batch.String())
+			batch.Reset()
+		}
+		if batch.Len() > 0 {
+			batch.WriteString(",")
+		}
+		batch.WriteString(escaped)
+	}
+	if batch.Len() > 0 {
+		batches = append(batches, batch.String())
+	}
+	return batches
+}
+
+// joinIndexNames joins resolved index names into a path segment, escaping
+// every name.
+func joinIndexNames(names []string) string {
+	escaped := make([]string, 0, len(names))
+	for _, name := range names {
+		escaped = append(escaped, url.PathEscape(name))
+	}
+	return strings.Join(escaped, ",")
+}
@@ -0,0 +1,84 @@
+package es
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolveIndicesRefusesEveryIndex(t *testing.T) {
+	for _, pattern := range []string{"", " ", "*", "_all", "**", "logs-*,*"} {
+		if _, err := ResolveIndices(context.Background(), pattern, false); err == nil {
+			t.Errorf("ResolveIndices(%q) succeeded, want an error", pattern)
+		}
+	}
+}
+
+func TestMatchesAllIndices(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    bool
+	}{
+		{"*", true},
+		{"_all", true},
+		{"logs-*, *", true},
+		{"logs-*", false},
+		{"logs-1,", false},
+		{"*-2024", false},
+	}
+	for _, tt := range tests {
+		if got := matchesAllIndices(tt.pattern); got != tt.want {
+			t.Errorf("matchesAllIndices(%q) = %t, want %t", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestDeleteIndicesEscapesNames(t *testing.T) {
+	withServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.EscapedPath(), "/logs-1,100%25"; got != want {
+			t.Errorf("got path %s, want %s", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"acknowledged": true}`))
+	})
+
+	response, err := DeleteIndices(context.Background(), []string{"logs-1", "100%"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !response.Acknowledged {
+		t.Errorf("got %+v, want acknowledged", response)
+	}
+}
+
+func TestDeleteIndicesInBatches(t *testing.T) {
+	names := make([]string, 500)
+	for i := range names {
+		names[i] = fmt.Sprintf("logs-2024.01.01-%03d", i)
+	}
+
+	var deleted []string
+	withServer(t, func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		if len(path) > maxIndexNamesLength {
+			t.Errorf("got a path of %d bytes, want at most %d", len(path), maxIndexNamesLength)
+		}
+		deleted = append(deleted, strings.Split(path, ",")...)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"acknowledged": true}`))
+	})
+
+	response, err := DeleteIndices(context.Background(), names)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !response.Acknowledged {
+		t.Errorf("got %+v, want acknowledged", response)
+	}
+	if !reflect.DeepEqual(deleted, names) {
+		t.Errorf("got %d deleted indices, want %d", len(deleted), len(names))
+	}
+}
@@ -0,0 +1,443 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pincher95/esctl/es/distribution"
+)
+
+// IndexLifecycle is the lifecycle state of an index as reported by
+// _ilm/explain, or by _plugins/_ism/explain on OpenSearch, where the phase is
+// the ISM state.
+type IndexLifecycle struct {
+	Index                string                 `json:"index,omitempty"`
+	Managed              bool                   `json:"managed"`
+	Policy               string                 `json:"policy,omitempty"`
+	Phase                string                 `json:"phase,omitempty"`
+	Action               string                 `json:"action,omitempty"`
+	Step                 string                 `json:"step,omitempty"`
+	Age                  string                 `json:"age,omitempty"`
+	PhaseTimeMillis      int64                  `json:"phase_time_millis,omitempty"`
+	ActionTimeMillis     int64                  `json:"action_time_millis,omitempty"`
+	StepTimeMillis       int64                  `json:"step_time_millis,omitempty"`
+	FailedStep           string                 `json:"failed_step,omitempty"`
+	FailedStepRetryCount int                    `json:"failed_step_retry_count,omitempty"`
+	AutoRetryableError   bool                   `json:"is_auto_retryable_error,omitempty"`
+	StepInfo             map[string]interface{} `json:"step_info,omitempty"`
+}
+
+// Failed reports whether the index is stuck in a failed step.
+func (l IndexLifecycle) Failed() bool {
+	return l.FailedStep != ""
+}
+
+// StepInfoSummary is the reason a step failed or is waiting, e.g.
+// 'illegal_argument_exception: rollover alias [logs] can point to multiple indices'.
+func (l IndexLifecycle) StepInfoSummary() string {
+	info := func(key string) string {
+		if value, ok := l.StepInfo[key].(string); ok {
+			return value
+		}
+		return ""
+	}
+
+	summary := info("reason")
+	if summary == "" {
+		summary = info("message")
+	}
+	if kind := info("type"); kind != "" && summary != "" {
+		summary = kind + ": " + summary
+	}
+	return strings.Join(strings.Fields(summary), " ")
+}
+
+// LifecyclePolicy is an ILM policy, or an ISM policy on OpenSearch where
+// the phases are the ISM states.
+type LifecyclePolicy struct {
+	Name         string           `json:"name"`
+	Version      int              `json:"version,omitempty"`
+	Modified     string           `json:"modified_date,omitempty"`
+	Description  string           `json:"description,omitempty"`
+	DefaultState string           `json:"default_state,omitempty"`
+	Phases       []LifecyclePhase `json:"phases"`
+	Indices      []string         `json:"in_use_by_indices,omitempty"`
+	DataStreams  []string         `json:"in_use_by_data_streams,omitempty"`
+	Templates    []string         `json:"in_use_by_templates,omitempty"`
+	Definition   interface{}      `json:"policy"`
+}
+
+// LifecyclePhase is a phase of an ILM policy or a state of an ISM policy with
+// the actions it runs and, for ISM, the transitions to other states.
+type LifecyclePhase struct {
+	Name        string   `json:"name"`
+	MinAge      string   `json:"min_age,omitempty"`
+	Actions     []string `json:"actions"`
+	Transitions []string `json:"transitions,omitempty"`
+}
+
+// ilmPhaseOrder is the order ILM runs phases in.
+var ilmPhaseOrder = map[string]int{"new": 0, "hot": 1, "warm": 2, "cold": 3, "frozen": 4, "delete": 5}
+
+type ilmPolicy struct {
+	Version  int    `json:"version"`
+	Modified string `json:"modified_date"`
+	Policy   struct {
+		Phases map[string]struct {
+			MinAge  string                     `json:"min_age"`
+			Actions map[string]json.RawMessage `json:"actions"`
+		} `json:"phases"`
+	} `json:"policy"`
+	InUseBy struct {
+		Indices             []string `json:"indices"`
+		DataStreams         []string `json:"data_streams"`
+		ComposableTemplates []string `json:"composable_templates"`
+	} `json:"in_use_by"`
+}
+
+func (p ilmPolicy) lifecyclePolicy(name string, definition json.RawMessage) LifecyclePolicy {
+	policy := LifecyclePolicy{
+		Name:        name,
+		Version:     p.Version,
+		Modified:    p.Modified,
+		Phases:      []LifecyclePhase{},
+		Indices:     p.InUseBy.Indices,
+		DataStreams: p.InUseBy.DataStreams,
+		Templates:   p.InUseBy.ComposableTemplates,
+		Definition:  definition,
+	}
+	for phaseName, phase := range p.Policy.Phases {
+		actions := make([]string, 0, len(phase.Actions))
+		for action := range phase.Actions {
+			actions = append(actions, action)
+		}
+		sort.Strings(actions)
+		policy.Phases = append(policy.Phases, LifecyclePhase{Name: phaseName, MinAge: phase.MinAge, Actions: actions})
+	}
+	sort.Slice(policy.Phases, func(i, j int) bool {
+		return ilmPhaseOrder[policy.Phases[i].Name] < ilmPhaseOrder[policy.Phases[j].Name]
+	})
+	return policy
+}
+
+// ismPolicy is an ISM policy as returned by _plugins/_ism/policies.
+type ismPolicy struct {
+	ID      string `json:"_id"`
+	Version int    `json:"_version"`
+	Policy  struct {
+		PolicyID        string `json:"policy_id"`
+		Description     string `json:"description"`
+		LastUpdatedTime int64  `json:"last_updated_time"`
+		DefaultState    string `json:"default_state"`
+		States          []struct {
+			Name        string                       `json:"name"`
+			Actions     []map[string]json.RawMessage `json:"actions"`
+			Transitions []struct {
+				StateName  string                 `json:"state_name"`
+				Conditions map[string]interface{} `json:"conditions"`
+			} `json:"transitions"`
+		} `json:"states"`
+	} `json:"policy"`
+}
+
+// ismActionSettings are the keys of an ISM action next to the action itself.
+var ismActionSettings = map[string]bool{"retry": true, "timeout": true, "custom_action": true}
+
+func (p ismPolicy) lifecyclePolicy(definition json.RawMessage) LifecyclePolicy {
+	policy := LifecyclePolicy{
+		Name:         p.ID,
+		Version:      p.Version,
+		Description:  p.Policy.Description,
+		DefaultState: p.Policy.DefaultState,
+		Phases:       []LifecyclePhase{},
+		Definition:   definition,
+	}
+	if p.Policy.LastUpdatedTime > 0 {
+		policy.Modified = time.UnixMilli(p.Policy.LastUpdatedTime).UTC().Format("2006-01-02T15:04:05.000Z")
+	}
+
+	for _, state := range p.Policy.States {
+		phase := LifecyclePhase{Name: state.Name, Actions: []string{}}
+		for _, action := range state.Actions {
+			for name := range action {
+				if !ismActionSettings[name] {
+					phase.Actions = append(phase.Actions, name)
+				}
+			}
+		}
+		for _, transition := range state.Transitions {
+			var conditions []string
+			for condition, value := range transition.Conditions {
+				conditions = append(conditions, fmt.Sprintf("%s %v", condition, value))
+			}
+			sort.Strings(conditions)
+			if len(conditions) == 0 {
+				phase.Transitions = append(phase.Transitions, transition.StateName)
+			} else {
+				phase.Transitions = append(phase.Transitions, fmt.Sprintf("%s after %s", transition.StateName, strings.Join(conditions, ", ")))
+			}
+		}
+		policy.Phases = append(policy.Phases, phase)
+	}
+	return policy
+}
+
+// GetLifecyclePolicies lists the ILM policies, or the ISM policies on
+// OpenSearch, sorted by name.
+func GetLifecyclePolicies(ctx context.Context) ([]LifecyclePolicy, error) {
+	policies := []LifecyclePolicy{}
+
+	if distribution.Detect(ctx).IsOpenSearch() {
+		var response struct {
+			Policies []json.RawMessage `json:"policies"`
+		}
+		if err := getJSONResponse(ctx, "_plugins/_ism/policies?size=1000", &response); err != nil {
+			return nil, err
+		}
+		for _, raw := range response.Policies {
+			var policy ismPolicy
+			if err := json.Unmarshal(raw, &policy); err != nil {
+				return nil, err
+			}
+			policies = append(policies, policy.lifecyclePolicy(ismDefinition(raw)))
+		}
+	} else {
+		var response map[string]json.RawMessage
+		if err := getJSONResponse(ctx, "_ilm/policy", &response); err != nil {
+			return nil, err
+		}
+		for name, raw := range response {
+			policy, err := parseILMPolicy(name, raw)
+			if err != nil {
+				return nil, err
+			}
+			policies = append(policies, policy)
+		}
+	}
+
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Name < policies[j].Name
+	})
+	return policies, nil
+}
+
+// GetLifecyclePolicy returns an ILM policy, or an ISM policy on OpenSearch.
+func GetLifecyclePolicy(ctx context.Context, name string) (*LifecyclePolicy, error) {
+	if distribution.Detect(ctx).IsOpenSearch() {
+		var raw json.RawMessage
+		if err := getJSONResponse(ctx, "_plugins/_ism/policies/"+url.PathEscape(name), &raw); err != nil {
+			return nil, err
+		}
+		var policy ismPolicy
+		if err := json.Unmarshal(raw, &policy); err != nil {
+			return nil, err
+		}
+		result := policy.lifecyclePolicy(ismDefinition(raw))
+		return &result, nil
+	}
+
+	var response map[string]json.RawMessage
+	if err := getJSONResponse(ctx, "_ilm/policy/"+url.PathEscape(name), &response); err != nil {
+		return nil, err
+	}
+	raw, ok := response[name]
+	if !ok {
+		return nil, fmt.Errorf("policy %s missing from response", name)
+	}
+	policy, err := parseILMPolicy(name, raw)
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func parseILMPolicy(name string, raw json.RawMessage) (LifecyclePolicy, error) {
+	var policy ilmPolicy
+	if err := json.Unmarshal(raw, &policy); err != nil {
+		return LifecyclePolicy{}, err
+	}
+	var definition struct {
+		Policy json.RawMessage `json:"policy"`
+	}
+	if err := json.Unmarshal(raw, &definition); err != nil {
+		return LifecyclePolicy{}, err
+	}
+	return policy.lifecyclePolicy(name, definition.Policy), nil
+}
+
+func ismDefinition(raw json.RawMessage) json.RawMessage {
+	var definition struct {
+		Policy json.RawMessage `json:"policy"`
+	}
+	_ = json.Unmarshal(raw, &definition)
+	return definition.Policy
+}
+
+// ismExplainIndex is the ISM state of an index as returned by
+// _plugins/_ism/explain.
+type ismExplainIndex struct {
+	Index    string  `json:"index"`
+	PolicyID *string `json:"policy_id"`
+	Enabled  *bool   `json:"enabled"`
+	State    *struct {
+		Name      string `json:"name"`
+		StartTime int64  `json:"start_time"`
+	} `json:"state"`
+	Action *struct {
+		Name            string `json:"name"`
+		StartTime       int64  `json:"start_time"`
+		Failed          bool   `json:"failed"`
+		ConsumedRetries int    `json:"consumed_retries"`
+	} `json:"action"`
+	Step *struct {
+		Name       string `json:"name"`
+		StartTime  int64  `json:"start_time"`
+		StepStatus string `json:"step_status"`
+	} `json:"step"`
+	RetryInfo *struct {
+		Failed          bool `json:"failed"`
+		ConsumedRetries int  `json:"consumed_retries"`
+	} `json:"retry_info"`
+	Info map[string]interface{} `json:"info"`
+}
+
+func (i ismExplainIndex) lifecycle(name string) IndexLifecycle {
+	lifecycle := IndexLifecycle{Index: name}
+	if i.PolicyID == nil || *i.PolicyID == "" {
+		return lifecycle
+	}
+
+	lifecycle.Managed = true
+	lifecycle.Policy = *i.PolicyID
+	lifecycle.StepInfo = i.Info
+	if i.State != nil {
+		lifecycle.Phase = i.State.Name
+		lifecycle.PhaseTimeMillis = i.State.StartTime
+	}
+	if i.Action != nil {
+		lifecycle.Action = i.Action.Name
+		lifecycle.ActionTimeMillis = i.Action.StartTime
+		lifecycle.FailedStepRetryCount = i.Action.ConsumedRetries
+	}
+	if i.Step != nil {
+		lifecycle.Step = i.Step.Name
+		lifecycle.StepTimeMillis = i.Step.StartTime
+	}
+	if i.RetryInfo != nil && i.RetryInfo.ConsumedRetries > lifecycle.FailedStepRetryCount {
+		lifecycle.FailedStepRetryCount = i.RetryInfo.ConsumedRetries
+	}
+
+	failed := i.Action != nil && i.Action.Failed || i.RetryInfo != nil && i.RetryInfo.Failed ||
+		i.Step != nil && i.Step.StepStatus == "failed"
+	if failed {
+		lifecycle.FailedStep = lifecycle.Step
+		if lifecycle.FailedStep == "" {
+			lifecycle.FailedStep = lifecycle.Action
+		}
+	}
+	return lifecycle
+}
+
+// ExplainLifecycle returns the ILM state of the indices matching index, or
+// their ISM state on OpenSearch, sorted by name. onlyErrors keeps the
+// indices stuck in a failed step, onlyManaged the ones with a policy.
+func ExplainLifecycle(ctx context.Context, index string, onlyErrors, onlyManaged bool) ([]IndexLifecycle, error) {
+	lifecycles := []IndexLifecycle{}
+
+	if distribution.Detect(ctx).IsOpenSearch() {
+		var response map[string]json.RawMessage
+		if err := getJSONResponse(ctx, "_plugins/_ism/explain/"+index, &response); err != nil {
+			return nil, err
+		}
+		for name, raw := range response {
+			// The response also counts the managed indices next to them
+			if name == "total_managed_indices" {
+				continue
+			}
+			var explain ismExplainIndex
+			if err := json.Unmarshal(raw, &explain); err != nil {
+				return nil, err
+			}
+			lifecycles = append(lifecycles, explain.lifecycle(name))
+		}
+	} else {
+		values := url.Values{}
+		if onlyErrors {
+			values.Set("only_errors", "true")
+		}
+		if onlyManaged {
+			values.Set("only_managed", "true")
+		}
+		endpoint := index + "/_ilm/explain"
+		if len(values) > 0 {
+			endpoint += "?" + values.Encode()
+		}
+
+		var response struct {
+			Indices map[string]IndexLifecycle `json:"indices"`
+		}
+		if err := getJSONResponse(ctx, endpoint, &response); err != nil {
+			return nil, err
+		}
+		for name, lifecycle := range response.Indices {
+			lifecycle.Index = name
+			lifecycles = append(lifecycles, lifecycle)
+		}
+	}
+
+	filtered := []IndexLifecycle{}
+	for _, lifecycle := range lifecycles {
+		if onlyErrors && !lifecycle.Failed() || onlyManaged && !lifecycle.Managed {
+			continue
+		}
+		filtered = append(filtered, lifecycle)
+	}
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].Index < filtered[j].Index
+	})
+	return filtered, nil
+}
+
+// RetryLifecycle re-runs the failed step of indices, with ISM on OpenSearch.
+// Many indices are retried in batches, see batchIndexNames.
+func RetryLifecycle(ctx context.Context, names []string) error {
+	if distribution.Detect(ctx).IsOpenSearch() {
+		var failures []string
+		failed := false
+		for _, batch := range batchIndexNames(names) {
+			var response struct {
+				Failures      bool `json:"failures"`
+				FailedIndices []struct {
+					IndexName string `json:"index_name"`
+					Reason    string `json:"reason"`
+				} `json:"failed_indices"`
+			}
+			if err := postWithoutBody(ctx, "_plugins/_ism/retry/"+batch, &response); err != nil {
+				return err
+			}
+			if response.Failures {
+				failed = true
+			}
+			for _, index := range response.FailedIndices {
+				failures = append(failures, fmt.Sprintf("%s: %s", index.IndexName, index.Reason))
+			}
+		}
+		if failed {
+			return fmt.Errorf("retry failed for %s", strings.Join(failures, "; "))
+		}
+		return nil
+	}
+
+	for _, batch := range batchIndexNames(names) {
+		var response Acknowledgement
+		if err := postWithoutBody(ctx, batch+"/_ilm/retry", &response); err != nil {
+			return err
+		}
+	}
+	return nil
+}
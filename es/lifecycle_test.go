@@ -0,0 +1,169 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseILMPolicy(t *testing.T) {
+	raw := json.RawMessage(`{
+		"version": 3,
+		"modified_date": "2024-05-01T10:00:00.000Z",
+		"policy": {"phases": {
+			"delete": {"min_age": "30d", "actions": {"delete": {}}},
+			"hot": {"min_age": "0ms", "actions": {"set_priority": {"priority": 100}, "rollover": {"max_age": "1d"}}},
+			"warm": {"min_age": "7d", "actions": {"shrink": {"number_of_shards": 1}}}
+		}},
+		"in_use_by": {"indices": ["logs-1"], "data_streams": [], "composable_templates": ["logs"]}
+	}`)
+
+	policy, err := parseILMPolicy("logs", raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []LifecyclePhase{
+		{Name: "hot", MinAge: "0ms", Actions: []string{"rollover", "set_priority"}},
+		{Name: "warm", MinAge: "7d", Actions: []string{"shrink"}},
+		{Name: "delete", MinAge: "30d", Actions: []string{"delete"}},
+	}
+	if !reflect.DeepEqual(policy.Phases, want) {
+		t.Errorf("phases: got %+v, want %+v", policy.Phases, want)
+	}
+	if policy.Version != 3 || !reflect.DeepEqual(policy.Indices, []string{"logs-1"}) || !reflect.DeepEqual(policy.Templates, []string{"logs"}) {
+		t.Errorf("got %+v", policy)
+	}
+}
+
+func TestISMPolicy(t *testing.T) {
+	raw := []byte(`{
+		"_id": "logs",
+		"_version": 2,
+		"policy": {
+			"policy_id": "logs",
+			"description": "Roll over and delete logs",
+			"last_updated_time": 1714557600000,
+			"default_state": "hot",
+			"states": [
+				{"name": "hot", "actions": [{"retry": {"count": 3}, "rollover": {"min_size": "50gb"}}], "transitions": [{"state_name": "delete", "conditions": {"min_index_age": "30d"}}]},
+				{"name": "delete", "actions": [{"delete": {}}], "transitions": []}
+			]
+		}
+	}`)
+
+	var policy ismPolicy
+	if err := json.Unmarshal(raw, &policy); err != nil {
+		t.Fatal(err)
+	}
+	got := policy.lifecyclePolicy(nil)
+
+	want := []LifecyclePhase{
+		{Name: "hot", Actions: []string{"rollover"}, Transitions: []string{"delete after min_index_age 30d"}},
+		{Name: "delete", Actions: []string{"delete"}},
+	}
+	if !reflect.DeepEqual(got.Phases, want) {
+		t.Errorf("states: got %+v, want %+v", got.Phases, want)
+	}
+	if got.Modified != "2024-05-01T10:00:00.000Z" || got.DefaultState != "hot" || got.Version != 2 {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func TestISMExplainLifecycle(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want IndexLifecycle
+	}{
+		{
+			name: "unmanaged",
+			raw:  `{"index.plugins.index_state_management.policy_id": null, "policy_id": null, "enabled": null}`,
+			want: IndexLifecycle{Index: "logs-1"},
+		},
+		{
+			name: "failed",
+			raw: `{"index": "logs-1", "policy_id": "logs", "enabled": true,
+				"state": {"name": "hot", "start_time": 1714557600000},
+				"action": {"name": "rollover", "start_time": 1714557700000, "failed": true, "consumed_retries": 3},
+				"step": {"name": "attempt_rollover", "start_time": 1714557800000, "step_status": "failed"},
+				"retry_info": {"failed": true, "consumed_retries": 3},
+				"info": {"message": "Missing rollover_alias index setting [index=logs-1]"}}`,
+			want: IndexLifecycle{
+				Index: "logs-1", Managed: true, Policy: "logs", Phase: "hot", Action: "rollover", Step: "attempt_rollover",
+				PhaseTimeMillis: 1714557600000, ActionTimeMillis: 1714557700000, StepTimeMillis: 1714557800000,
+				FailedStep: "attempt_rollover", FailedStepRetryCount: 3,
+				StepInfo: map[string]interface{}{"message": "Missing rollover_alias index setting [index=logs-1]"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var explain ismExplainIndex
+			if err := json.Unmarshal([]byte(tt.raw), &explain); err != nil {
+				t.Fatal(err)
+			}
+			if got := explain.lifecycle("logs-1"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStepInfoSummary(t *testing.T) {
+	tests := []struct {
+		info map[string]interface{}
+		want string
+	}{
+		{map[string]interface{}{"type": "illegal_argument_exception", "reason": "rollover alias [logs]\n can point to multiple indices"}, "illegal_argument_exception: rollover alias [logs] can point to multiple indices"},
+		{map[string]interface{}{"message": "Waiting for all shard copies to be active", "all_shards_active": false}, "Waiting for all shard copies to be active"},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := (IndexLifecycle{StepInfo: tt.info}).StepInfoSummary(); got != tt.want {
+			t.Errorf("got %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestRetryLifecycleInBatches(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	names := make([]string, 500)
+	for i := range names {
+		names[i] = fmt.Sprintf("logs-2024.01.01-%03d", i)
+	}
+
+	var retried []string
+	withServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		path := r.URL.EscapedPath()
+		switch {
+		case path == "/":
+			_, _ = w.Write([]byte(`{"version": {"number": "8.11.1", "build_flavor": "default"}}`))
+			return
+		case strings.HasPrefix(path, "/_plugins/_ism/retry/"):
+			path = strings.TrimPrefix(path, "/_plugins/_ism/retry/")
+		case strings.HasSuffix(path, "/_ilm/retry"):
+			path = strings.TrimSuffix(strings.TrimPrefix(path, "/"), "/_ilm/retry")
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, path)
+		}
+		if len(path) > maxIndexNamesLength {
+			t.Errorf("got %d bytes of index names, want at most %d", len(path), maxIndexNamesLength)
+		}
+		retried = append(retried, strings.Split(path, ",")...)
+		_, _ = w.Write([]byte(`{"acknowledged": true, "failures": false}`))
+	})
+
+	if err := RetryLifecycle(context.Background(), names); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(retried, names) {
+		t.Errorf("got %d retried indices, want %d", len(retried), len(names))
+	}
+}
@@ -0,0 +1,344 @@
+package es
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pincher95/esctl/es/cat"
+	"github.com/pincher95/esctl/es/distribution"
+	"github.com/pincher95/esctl/internal/client"
+)
+
+// NodeDescription is everything 'describe node' shows about one node.
+type NodeDescription struct {
+	ID               string            `json:"id"`
+	Name             string            `json:"name"`
+	Host             string            `json:"host"`
+	IP               string            `json:"ip"`
+	TransportAddress string            `json:"transport_address"`
+	Version          string            `json:"version"`
+	Roles            []string          `json:"roles"`
+	Attributes       map[string]string `json:"attributes"`
+	OS               NodeOS            `json:"os"`
+	JVM              NodeJVM           `json:"jvm"`
+	Disk             NodeDisk          `json:"disk"`
+	Indices          []NodeIndex       `json:"indices"`
+	// ThreadPools only lists pools with active or queued tasks or rejections
+	ThreadPools []NodeThreadPool `json:"thread_pools"`
+}
+
+type NodeOS struct {
+	Name                string             `json:"name"`
+	Version             string             `json:"version"`
+	Arch                string             `json:"arch"`
+	AvailableProcessors int                `json:"available_processors"`
+	CPUPercent          int                `json:"cpu_percent"`
+	LoadAverage         map[string]float64 `json:"load_average,omitempty"`
+	MemTotalBytes       int64              `json:"mem_total_in_bytes"`
+	MemUsedBytes        int64              `json:"mem_used_in_bytes"`
+	MemUsedPercent      int                `json:"mem_used_percent"`
+}
+
+type NodeJVM struct {
+	Version         string                 `json:"version"`
+	VMName          string                 `json:"vm_name"`
+	UptimeMillis    int64                  `json:"uptime_in_millis"`
+	HeapUsedBytes   int64                  `json:"heap_used_in_bytes"`
+	HeapMaxBytes    int64                  `json:"heap_max_in_bytes"`
+	HeapUsedPercent int                    `json:"heap_used_percent"`
+	GC              map[string]NodeGCStats `json:"gc"`
+}
+
+type NodeGCStats struct {
+	Count      int64 `json:"collection_count"`
+	TimeMillis int64 `json:"collection_time_in_millis"`
+}
+
+type NodeDisk struct {
+	TotalBytes     int64   `json:"total_in_bytes"`
+	FreeBytes      int64   `json:"free_in_bytes"`
+	AvailableBytes int64   `json:"available_in_bytes"`
+	UsedPercent    float64 `json:"used_percent"`
+	// Watermarks are percentages like '85%' or free space like '100gb'
+	WatermarkLow        string `json:"watermark_low,omitempty"`
+	WatermarkHigh       string `json:"watermark_high,omitempty"`
+	WatermarkFloodStage string `json:"watermark_flood_stage,omitempty"`
+}
+
+// NodeIndex is the shards of one index hosted on a node.
+type NodeIndex struct {
+	Index      string `json:"index"`
+	Primaries  []int  `json:"primaries"`
+	Replicas   []int  `json:"replicas"`
+	Docs       int64  `json:"docs"`
+	StoreBytes int64  `json:"store_in_bytes"`
+}
+
+type NodeThreadPool struct {
+	Name      string `json:"name"`
+	Threads   int    `json:"threads"`
+	Active    int    `json:"active"`
+	Queue     int    `json:"queue"`
+	Largest   int    `json:"largest"`
+	Rejected  int64  `json:"rejected"`
+	Completed int64  `json:"completed"`
+}
+
+type nodesInfoResponse struct {
+	Nodes map[string]struct {
+		Name             string            `json:"name"`
+		Host             string            `json:"host"`
+		IP               string            `json:"ip"`
+		TransportAddress string            `json:"transport_address"`
+		Version          string            `json:"version"`
+		Roles            []string          `json:"roles"`
+		Attributes       map[string]string `json:"attributes"`
+		OS               struct {
+			Name                string `json:"name"`
+			Version             string `json:"version"`
+			Arch                string `json:"arch"`
+			AvailableProcessors int    `json:"available_processors"`
+		} `json:"os"`
+		JVM struct {
+			Version string `json:"version"`
+			VMName  string `json:"vm_name"`
+		} `json:"jvm"`
+	} `json:"nodes"`
+}
+
+type nodesStatsResponse struct {
+	Nodes map[string]struct {
+		OS struct {
+			CPU struct {
+				Percent     int                `json:"percent"`
+				LoadAverage map[string]float64 `json:"load_average"`
+			} `json:"cpu"`
+			Mem struct {
+				TotalBytes  int64 `json:"total_in_bytes"`
+				UsedBytes   int64 `json:"used_in_bytes"`
+				UsedPercent int   `json:"used_percent"`
+			} `json:"mem"`
+		} `json:"os"`
+		JVM struct {
+			UptimeMillis int64 `json:"uptime_in_millis"`
+			Mem          struct {
+				HeapUsedBytes   int64 `json:"heap_used_in_bytes"`
+				HeapUsedPercent int   `json:"heap_used_percent"`
+				HeapMaxBytes    int64 `json:"heap_max_in_bytes"`
+			} `json:"mem"`
+			GC struct {
+				Collectors map[string]NodeGCStats `json:"collectors"`
+			} `json:"gc"`
+		} `json:"jvm"`
+		FS struct {
+			Total struct {
+				TotalBytes     int64 `json:"total_in_bytes"`
+				FreeBytes      int64 `json:"free_in_bytes"`
+				AvailableBytes int64 `json:"available_in_bytes"`
+			} `json:"total"`
+		} `json:"fs"`
+		ThreadPool map[string]struct {
+			Threads   int   `json:"threads"`
+			Queue     int   `json:"queue"`
+			Active    int   `json:"active"`
+			Rejected  int64 `json:"rejected"`
+			Largest   int   `json:"largest"`
+			Completed int64 `json:"completed"`
+		} `json:"thread_pool"`
+	} `json:"nodes"`
+}
+
+// diskWatermarkSettings holds the flat cluster settings. Values are not all
+// strings, e.g. discovery.seed_hosts is a list, so only the string settings
+// are read from them.
+type diskWatermarkSettings struct {
+	Persistent map[string]interface{} `json:"persistent"`
+	Transient  map[string]interface{} `json:"transient"`
+	Defaults   map[string]interface{} `json:"defaults"`
+}
+
+// setting returns a flat cluster setting, transient settings win over
+// persistent ones which win over defaults.
+func (s diskWatermarkSettings) setting(name string) string {
+	for _, settings := range []map[string]interface{}{s.Transient, s.Persistent, s.Defaults} {
+		if value, ok := settings[name].(string); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+// nodeRoles sorts the roles of a node, calling the master role
+// cluster_manager on clusters using that name, where nodes configured with
+// the deprecated role may still report master.
+func nodeRoles(roles []string, info distribution.Info) []string {
+	sorted := make([]string, 0, len(roles))
+	seen := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		if role == "master" && info.UsesClusterManager() {
+			role = "cluster_manager"
+		}
+		if !seen[role] {
+			seen[role] = true
+			sorted = append(sorted, role)
+		}
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// DescribeNodes gathers roles, attributes, OS, JVM, disk usage against the
+// allocation watermarks, hosted shards and busy thread pools of the nodes
+// matching node, which may be a node ID, name, address or pattern.
+func DescribeNodes(ctx context.Context, node string) ([]NodeDescription, error) {
+	nodeFilter := url.PathEscape(node)
+
+	var info nodesInfoResponse
+	if err := getJSONResponse(ctx, "_nodes/"+nodeFilter, &info); err != nil {
+		return nil, fmt.Errorf("failed to get node info: %w", err)
+	}
+	if len(info.Nodes) == 0 {
+		return nil, &client.NotFoundError{Kind: "node", Name: node}
+	}
+
+	dist := distribution.Detect(ctx)
+
+	var stats nodesStatsResponse
+	if err := getJSONResponse(ctx, "_nodes/"+nodeFilter+"/stats/os,jvm,fs,thread_pool", &stats); err != nil {
+		return nil, fmt.Errorf("failed to get node stats: %w", err)
+	}
+
+	// Reading cluster settings needs more privileges than monitoring, so watermarks are optional
+	var watermarks diskWatermarkSettings
+	if err := getJSONResponse(ctx, "_cluster/settings?include_defaults=true&flat_settings=true", &watermarks); err != nil {
+		var respErr *client.ResponseError
+		if !errors.As(err, &respErr) {
+			return nil, fmt.Errorf("failed to get disk watermarks: %w", err)
+		}
+	}
+
+	all, bytes := "", "b"
+	shards, err := cat.CatShards(ctx, nil, &all, &bytes, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptions := make([]NodeDescription, 0, len(info.Nodes))
+	for id, n := range info.Nodes {
+		s := stats.Nodes[id]
+
+		description := NodeDescription{
+			ID:               id,
+			Name:             n.Name,
+			Host:             n.Host,
+			IP:               n.IP,
+			TransportAddress: n.TransportAddress,
+			Version:          n.Version,
+			Roles:            n.Roles,
+			Attributes:       n.Attributes,
+			OS: NodeOS{
+				Name:                n.OS.Name,
+				Version:             n.OS.Version,
+				Arch:                n.OS.Arch,
+				AvailableProcessors: n.OS.AvailableProcessors,
+				CPUPercent:          s.OS.CPU.Percent,
+				LoadAverage:         s.OS.CPU.LoadAverage,
+				MemTotalBytes:       s.OS.Mem.TotalBytes,
+				MemUsedBytes:        s.OS.Mem.UsedBytes,
+				MemUsedPercent:      s.OS.Mem.UsedPercent,
+			},
+			JVM: NodeJVM{
+				Version:         n.JVM.Version,
+				VMName:          n.JVM.VMName,
+				UptimeMillis:    s.JVM.UptimeMillis,
+				HeapUsedBytes:   s.JVM.Mem.HeapUsedBytes,
+				HeapMaxBytes:    s.JVM.Mem.HeapMaxBytes,
+				HeapUsedPercent: s.JVM.Mem.HeapUsedPercent,
+				GC:              s.JVM.GC.Collectors,
+			},
+			Disk: NodeDisk{
+				TotalBytes:          s.FS.Total.TotalBytes,
+				FreeBytes:           s.FS.Total.FreeBytes,
+				AvailableBytes:      s.FS.Total.AvailableBytes,
+				WatermarkLow:        watermarks.setting("cluster.routing.allocation.disk.watermark.low"),
+				WatermarkHigh:       watermarks.setting("cluster.routing.allocation.disk.watermark.high"),
+				WatermarkFloodStage: watermarks.setting("cluster.routing.allocation.disk.watermark.flood_stage"),
+			},
+			Indices:     nodeIndices(shards, n.Name),
+			ThreadPools: []NodeThreadPool{},
+		}
+		if total := s.FS.Total.TotalBytes; total > 0 {
+			description.Disk.UsedPercent = float64(total-s.FS.Total.AvailableBytes) * 100 / float64(total)
+		}
+		description.Roles = nodeRoles(n.Roles, dist)
+
+		for name, pool := range s.ThreadPool {
+			if pool.Active == 0 && pool.Queue == 0 && pool.Rejected == 0 {
+				continue
+			}
+			description.ThreadPools = append(description.ThreadPools, NodeThreadPool{
+				Name:      name,
+				Threads:   pool.Threads,
+				Active:    pool.Active,
+				Queue:     pool.Queue,
+				Largest:   pool.Largest,
+				Rejected:  pool.Rejected,
+				Completed: pool.Completed,
+			})
+		}
+		sort.Slice(description.ThreadPools, func(i, j int) bool {
+			return description.ThreadPools[i].Name < description.ThreadPools[j].Name
+		})
+
+		descriptions = append(descriptions, description)
+	}
+
+	sort.Slice(descriptions, func(i, j int) bool {
+		return descriptions[i].Name < descriptions[j].Name
+	})
+
+	return descriptions, nil
+}
+
+// nodeIndices sums up the shards allocated to the named node per index.
+// Relocating shards are counted on the node they are moving away from.
+func nodeIndices(shards []cat.Shard, nodeName string) []NodeIndex {
+	byIndex := make(map[string]*NodeIndex)
+	for _, shard := range shards {
+		if node, _, _ := strings.Cut(stringValue(shard.Node), " -> "); node != nodeName {
+			continue
+		}
+
+		index, ok := byIndex[shard.Index]
+		if !ok {
+			index = &NodeIndex{Index: shard.Index, Primaries: []int{}, Replicas: []int{}}
+			byIndex[shard.Index] = index
+		}
+
+		if shard.Prirep == "p" {
+			index.Primaries = append(index.Primaries, shard.Shard)
+		} else {
+			index.Replicas = append(index.Replicas, shard.Shard)
+		}
+		docs, _ := strconv.ParseInt(stringValue(shard.Docs), 10, 64)
+		store, _ := strconv.ParseInt(stringValue(shard.Store), 10, 64)
+		index.Docs += docs
+		index.StoreBytes += store
+	}
+
+	indices := make([]NodeIndex, 0, len(byIndex))
+	for _, index := range byIndex {
+		sort.Ints(index.Primaries)
+		sort.Ints(index.Replicas)
+		indices = append(indices, *index)
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		return indices[i].Index < indices[j].Index
+	})
+	return indices
+}
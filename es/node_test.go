@@ -0,0 +1,91 @@
+package es
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/pincher95/esctl/es/cat"
+	"github.com/pincher95/esctl/es/distribution"
+)
+
+func TestNodeIndices(t *testing.T) {
+	str := func(s string) *string { return &s }
+	shards := []cat.Shard{
+		{Index: "logs", Shard: 1, Prirep: "p", Node: str("es-1"), Docs: str("10"), Store: str("100")},
+		{Index: "logs", Shard: 0, Prirep: "p", Node: str("es-1"), Docs: str("5"), Store: str("50")},
+		{Index: "logs", Shard: 2, Prirep: "r", Node: str("es-1 -> 10.0.0.2 abc es-2"), Docs: str("1"), Store: str("10")},
+		{Index: "logs", Shard: 2, Prirep: "p", Node: str("es-2"), Docs: str("1"), Store: str("10")},
+		{Index: "articles", Shard: 0, Prirep: "r", Node: str("es-1"), Docs: str("3"), Store: str("30")},
+		{Index: "articles", Shard: 0, Prirep: "p", State: "UNASSIGNED"},
+	}
+
+	want := []NodeIndex{
+		{Index: "articles", Primaries: []int{}, Replicas: []int{0}, Docs: 3, StoreBytes: 30},
+		{Index: "logs", Primaries: []int{0, 1}, Replicas: []int{2}, Docs: 16, StoreBytes: 160},
+	}
+	if got := nodeIndices(shards, "es-1"); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestDiskWatermarkSettingPrecedence(t *testing.T) {
+	const low = "cluster.routing.allocation.disk.watermark.low"
+	settings := diskWatermarkSettings{
+		Persistent: map[string]interface{}{low: "80%"},
+		Defaults:   map[string]interface{}{low: "85%", "cluster.routing.allocation.disk.watermark.high": "90%"},
+	}
+
+	if got := settings.setting(low); got != "80%" {
+		t.Errorf("low watermark: got %q, want persistent setting 80%%", got)
+	}
+	if got := settings.setting("cluster.routing.allocation.disk.watermark.high"); got != "90%" {
+		t.Errorf("high watermark: got %q, want default 90%%", got)
+	}
+
+	settings.Transient = map[string]interface{}{low: "75%"}
+	if got := settings.setting(low); got != "75%" {
+		t.Errorf("low watermark: got %q, want transient setting 75%%", got)
+	}
+}
+
+func TestDiskWatermarkSettingsWithListDefaults(t *testing.T) {
+	body := `{
+		"persistent": {},
+		"transient": {},
+		"defaults": {
+			"discovery.seed_hosts": [],
+			"node.roles": ["data", "master"],
+			"cluster.routing.allocation.disk.watermark.low": "85%"
+		}
+	}`
+
+	var settings diskWatermarkSettings
+	if err := json.Unmarshal([]byte(body), &settings); err != nil {
+		t.Fatal(err)
+	}
+	if got := settings.setting("cluster.routing.allocation.disk.watermark.low"); got != "85%" {
+		t.Errorf("low watermark: got %q, want default 85%%", got)
+	}
+	if got := settings.setting("node.roles"); got != "" {
+		t.Errorf("node.roles: got %q, want no string setting", got)
+	}
+}
+
+func TestNodeRoles(t *testing.T) {
+	roles := []string{"master", "data", "ingest", "cluster_manager"}
+
+	tests := []struct {
+		info distribution.Info
+		want []string
+	}{
+		{distribution.Info{Distribution: distribution.Elasticsearch, Version: "8.11.1"}, []string{"cluster_manager", "data", "ingest", "master"}},
+		{distribution.Info{Distribution: distribution.OpenSearch, Version: "1.3.0"}, []string{"cluster_manager", "data", "ingest", "master"}},
+		{distribution.Info{Distribution: distribution.OpenSearch, Version: "2.11.0"}, []string{"cluster_manager", "data", "ingest"}},
+	}
+	for _, tt := range tests {
+		if got := nodeRoles(roles, tt.info); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.info, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+)
+
+// Recovery types tell where a shard copy is recovered from.
+const (
+	RecoveryTypeSnapshot = "SNAPSHOT"
+	RecoveryTypePeer     = "PEER"
+)
+
+type recoveryResponse map[string]struct {
+	Shards []ShardRecovery `json:"shards"`
+}
+
+// ShardRecovery is the recovery of one shard copy as reported by _recovery.
+type ShardRecovery struct {
+	Index             string           `json:"index"`
+	ID                int              `json:"id"`
+	Type              string           `json:"type"`
+	Stage             string           `json:"stage"`
+	Primary           bool             `json:"primary"`
+	StartTimeInMillis int64            `json:"start_time_in_millis"`
+	StopTimeInMillis  int64            `json:"stop_time_in_millis,omitempty"`
+	TotalTimeInMillis int64            `json:"total_time_in_millis"`
+	Source            RecoverySource   `json:"source"`
+	Target            RecoveryNode     `json:"target"`
+	Files             RecoveryFiles    `json:"files"`
+	Translog          RecoveryTranslog `json:"translog"`
+}
+
+// RecoveryNode is the node a shard is recovered to or from.
+type RecoveryNode struct {
+	ID               string `json:"id,omitempty"`
+	Host             string `json:"host,omitempty"`
+	TransportAddress string `json:"transport_address,omitempty"`
+	IP               string `json:"ip,omitempty"`
+	Name             string `json:"name,omitempty"`
+}
+
+// RecoverySource is a node for peer recoveries, or a snapshot when a shard
+// is restored.
+type RecoverySource struct {
+	RecoveryNode
+	Repository string `json:"repository,omitempty"`
+	Snapshot   string `json:"snapshot,omitempty"`
+	Index      string `json:"index,omitempty"`
+}
+
+// RecoveryFiles is how much of a shard's files are recovered, both in bytes
+// and in number of files. Reused files were already present on the target.
+type RecoveryFiles struct {
+	TotalBytes     int64 `json:"total_bytes"`
+	ReusedBytes    int64 `json:"reused_bytes"`
+	RecoveredBytes int64 `json:"recovered_bytes"`
+	Total          int   `json:"total"`
+	Reused         int   `json:"reused"`
+	Recovered      int   `json:"recovered"`
+}
+
+type RecoveryTranslog struct {
+	Recovered int `json:"recovered"`
+	Total     int `json:"total"`
+}
+
+// UnmarshalJSON flattens the 'index' section of a shard recovery, which
+// holds the file and byte counts, into Files.
+func (r *ShardRecovery) UnmarshalJSON(data []byte) error {
+	type plain ShardRecovery
+	var recovery struct {
+		plain
+		IndexStats struct {
+			Size struct {
+				TotalInBytes     int64 `json:"total_in_bytes"`
+				ReusedInBytes    int64 `json:"reused_in_bytes"`
+				RecoveredInBytes int64 `json:"recovered_in_bytes"`
+			} `json:"size"`
+			Files struct {
+				Total     int `json:"total"`
+				Reused    int `json:"reused"`
+				Recovered int `json:"recovered"`
+			} `json:"files"`
+		} `json:"index"`
+	}
+	if err := json.Unmarshal(data, &recovery); err != nil {
+		return err
+	}
+
+	*r = ShardRecovery(recovery.plain)
+	r.Files = RecoveryFiles{
+		TotalBytes:     recovery.IndexStats.Size.TotalInBytes,
+		ReusedBytes:    recovery.IndexStats.Size.ReusedInBytes,
+		RecoveredBytes: recovery.IndexStats.Size.RecoveredInBytes,
+		Total:          recovery.IndexStats.Files.Total,
+		Reused:         recovery.IndexStats.Files.Reused,
+		Recovered:      recovery.IndexStats.Files.Recovered,
+	}
+	return nil
+}
+
+// GetRecoveries lists the shard recoveries of the indices matching index,
+// or of all indices when it is empty, sorted by index and shard. With
+// activeOnly, completed recoveries are left out.
+func GetRecoveries(ctx context.Context, index string, activeOnly bool) ([]ShardRecovery, error) {
+	endpoint := "_recovery"
+	if index != "" {
+		endpoint = index + "/_recovery"
+	}
+	if activeOnly {
+		endpoint += "?active_only=true"
+	}
+
+	var response recoveryResponse
+	if err := getJSONResponse(ctx, endpoint, &response); err != nil {
+		return nil, err
+	}
+
+	var recoveries []ShardRecovery
+	for name, index := range response {
+		for _, shard := range index.Shards {
+			shard.Index = name
+			recoveries = append(recoveries, shard)
+		}
+	}
+	sort.Slice(recoveries, func(i, j int) bool {
+		if recoveries[i].Index != recoveries[j].Index {
+			return recoveries[i].Index < recoveries[j].Index
+		}
+		if recoveries[i].ID != recoveries[j].ID {
+			return recoveries[i].ID < recoveries[j].ID
+		}
+		return recoveries[i].Primary && !recoveries[j].Primary
+	})
+	return recoveries, nil
+}
@@ -0,0 +1,516 @@
+package es
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pincher95/esctl/internal/client"
+)
+
+// Repository is a snapshot repository, e.g. a file system path or a bucket.
+type Repository struct {
+	Name     string                 `json:"name"`
+	Type     string                 `json:"type"`
+	Settings map[string]interface{} `json:"settings"`
+}
+
+// Location is where a repository keeps its snapshots, e.g. a path or
+// 'bucket/base_path', empty when the repository type has no location.
+func (r Repository) Location() string {
+	setting := func(key string) string {
+		if value, ok := r.Settings[key]; ok {
+			return fmt.Sprint(value)
+		}
+		return ""
+	}
+
+	if location := setting("location"); location != "" {
+		return location
+	}
+	for _, key := range []string{"bucket", "container"} {
+		if bucket := setting(key); bucket != "" {
+			if basePath := setting("base_path"); basePath != "" {
+				return bucket + "/" + basePath
+			}
+			return bucket
+		}
+	}
+	return setting("url")
+}
+
+// Snapshot is a snapshot as listed by the get snapshot API.
+type Snapshot struct {
+	Snapshot           string            `json:"snapshot"`
+	UUID               string            `json:"uuid"`
+	Repository         string            `json:"repository,omitempty"`
+	Version            string            `json:"version,omitempty"`
+	Indices            []string          `json:"indices"`
+	DataStreams        []string          `json:"data_streams,omitempty"`
+	IncludeGlobalState bool              `json:"include_global_state"`
+	State              string            `json:"state"`
+	Reason             string            `json:"reason,omitempty"`
+	StartTime          string            `json:"start_time,omitempty"`
+	StartTimeInMillis  int64             `json:"start_time_in_millis,omitempty"`
+	EndTime            string            `json:"end_time,omitempty"`
+	EndTimeInMillis    int64             `json:"end_time_in_millis,omitempty"`
+	DurationInMillis   int64             `json:"duration_in_millis"`
+	Failures           []SnapshotFailure `json:"failures"`
+	Shards             SnapshotShards    `json:"shards"`
+}
+
+type SnapshotFailure struct {
+	Index   string `json:"index"`
+	ShardID int    `json:"shard_id"`
+	NodeID  string `json:"node_id,omitempty"`
+	Status  string `json:"status,omitempty"`
+	Reason  string `json:"reason"`
+}
+
+type SnapshotShards struct {
+	Total      int `json:"total"`
+	Failed     int `json:"failed"`
+	Successful int `json:"successful"`
+}
+
+// SnapshotStats counts the files and bytes of a snapshot or one of its
+// shards. Incremental is what the snapshot had to copy, the rest was already
+// in the repository, and Processed is what is copied so far.
+type SnapshotStats struct {
+	Incremental       SnapshotFileStats `json:"incremental"`
+	Processed         SnapshotFileStats `json:"processed"`
+	Total             SnapshotFileStats `json:"total"`
+	StartTimeInMillis int64             `json:"start_time_in_millis"`
+	TimeInMillis      int64             `json:"time_in_millis"`
+}
+
+type SnapshotFileStats struct {
+	FileCount   int   `json:"file_count"`
+	SizeInBytes int64 `json:"size_in_bytes"`
+}
+
+// SnapshotStatus is the shard level progress of a snapshot as reported by
+// the snapshot status API.
+type SnapshotStatus struct {
+	Snapshot   string                         `json:"snapshot"`
+	Repository string                         `json:"repository"`
+	UUID       string                         `json:"uuid"`
+	State      string                         `json:"state"`
+	Stats      SnapshotStats                  `json:"stats"`
+	Indices    map[string]SnapshotIndexStatus `json:"indices"`
+}
+
+type SnapshotIndexStatus struct {
+	Stats  SnapshotStats                  `json:"stats"`
+	Shards map[string]SnapshotShardStatus `json:"shards"`
+}
+
+type SnapshotShardStatus struct {
+	Stage  string        `json:"stage"`
+	Node   string        `json:"node,omitempty"`
+	Reason string        `json:"reason,omitempty"`
+	Stats  SnapshotStats `json:"stats"`
+}
+
+// SnapshotDescription is everything 'describe snapshot' shows about one
+// snapshot.
+type SnapshotDescription struct {
+	Snapshot
+	Size       SnapshotFileStats            `json:"size"`
+	IndexSizes map[string]SnapshotFileStats `json:"index_sizes,omitempty"`
+}
+
+// CreateSnapshotRequest is the body of a create snapshot request. Indices
+// are comma-separated and may use wildcards, all indices when empty.
+type CreateSnapshotRequest struct {
+	Indices            string `json:"indices,omitempty"`
+	IncludeGlobalState bool   `json:"include_global_state"`
+	Partial            bool   `json:"partial"`
+}
+
+// RestoreSnapshotRequest is the body of a restore snapshot request. Indices
+// matching RenamePattern are restored under RenameReplacement, which may
+// refer to its groups, e.g. 'restored-$1'.
+type RestoreSnapshotRequest struct {
+	Indices            string `json:"indices,omitempty"`
+	RenamePattern      string `json:"rename_pattern,omitempty"`
+	RenameReplacement  string `json:"rename_replacement,omitempty"`
+	IncludeGlobalState bool   `json:"include_global_state"`
+	IncludeAliases     bool   `json:"include_aliases"`
+	Partial            bool   `json:"partial"`
+}
+
+// SnapshotResponse is the result of creating or restoring a snapshot. It
+// only holds the snapshot when waiting for completion, otherwise the request
+// is just accepted.
+type SnapshotResponse struct {
+	Accepted bool `json:"accepted,omitempty"`
+	Snapshot *struct {
+		Snapshot string         `json:"snapshot"`
+		Indices  []string       `json:"indices"`
+		State    string         `json:"state,omitempty"`
+		Shards   SnapshotShards `json:"shards"`
+	} `json:"snapshot,omitempty"`
+}
+
+// RestoredIndex is an index of a snapshot and the name it is restored under.
+type RestoredIndex struct {
+	Index      string `json:"index"`
+	RestoredAs string `json:"restored_as"`
+}
+
+// ShardSnapshotProgress is the progress of one shard being snapshotted or
+// restored from a snapshot.
+type ShardSnapshotProgress struct {
+	// Operation is 'snapshot' or 'restore'
+	Operation  string `json:"operation"`
+	Repository string `json:"repository"`
+	Snapshot   string `json:"snapshot"`
+	Index      string `json:"index"`
+	Shard      int    `json:"shard"`
+	Stage      string `json:"stage"`
+	Node       string `json:"node,omitempty"`
+	BytesTotal int64  `json:"bytes_total"`
+	BytesDone  int64  `json:"bytes_done"`
+	FilesTotal int    `json:"files_total"`
+	FilesDone  int    `json:"files_done"`
+}
+
+const (
+	OperationSnapshot = "snapshot"
+	OperationRestore  = "restore"
+)
+
+// GetRepositories lists the snapshot repositories sorted by name.
+func GetRepositories(ctx context.Context) ([]Repository, error) {
+	var response map[string]Repository
+	if err := getJSONResponse(ctx, "_snapshot", &response); err != nil {
+		return nil, err
+	}
+
+	repositories := make([]Repository, 0, len(response))
+	for name, repository := range response {
+		repository.Name = name
+		repositories = append(repositories, repository)
+	}
+	sort.Slice(repositories, func(i, j int) bool {
+		return repositories[i].Name < repositories[j].Name
+	})
+	return repositories, nil
+}
+
+// GetSnapshots lists the snapshots of repository matching pattern, all of
+// them when it is empty, from the oldest to the newest.
+func GetSnapshots(ctx context.Context, repository, pattern string) ([]Snapshot, error) {
+	if pattern == "" {
+		pattern = "_all"
+	}
+
+	var response struct {
+		Snapshots []Snapshot `json:"snapshots"`
+	}
+	endpoint := fmt.Sprintf("_snapshot/%s/%s", url.PathEscape(repository), pattern)
+	if err := getJSONResponse(ctx, endpoint, &response); err != nil {
+		return nil, err
+	}
+
+	for i := range response.Snapshots {
+		if response.Snapshots[i].Repository == "" {
+			response.Snapshots[i].Repository = repository
+		}
+	}
+	sort.SliceStable(response.Snapshots, func(i, j int) bool {
+		return response.Snapshots[i].StartTimeInMillis < response.Snapshots[j].StartTimeInMillis
+	})
+	return response.Snapshots, nil
+}
+
+// DescribeSnapshot gathers the details of a snapshot and the size of it and
+// every index in it from the snapshot status API.
+func DescribeSnapshot(ctx context.Context, repository, name string) (*SnapshotDescription, error) {
+	snapshots, err := GetSnapshots(ctx, repository, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, &client.NotFoundError{Kind: "snapshot", Name: name}
+	}
+
+	statuses, err := getSnapshotStatuses(ctx, fmt.Sprintf("_snapshot/%s/%s/_status", url.PathEscape(repository), url.PathEscape(name)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshot status: %w", err)
+	}
+
+	description := &SnapshotDescription{Snapshot: snapshots[0]}
+	for _, status := range statuses {
+		description.Size = status.Stats.Total
+		description.IndexSizes = make(map[string]SnapshotFileStats, len(status.Indices))
+		for index, indexStatus := range status.Indices {
+			description.IndexSizes[index] = indexStatus.Stats.Total
+		}
+	}
+	return description, nil
+}
+
+// CreateSnapshot starts a snapshot, and waits for it to finish when wait is set.
+func CreateSnapshot(ctx context.Context, repository, name string, request CreateSnapshotRequest, wait bool) (*SnapshotResponse, error) {
+	endpoint := fmt.Sprintf("_snapshot/%s/%s?wait_for_completion=%t", url.PathEscape(repository), url.PathEscape(name), wait)
+
+	// Snapshots are accepted with 202 and created with 200 when waited for
+	expected := http.StatusOK
+	if !wait {
+		expected = http.StatusAccepted
+	}
+
+	var response SnapshotResponse
+	if err := httpRequest(ctx, http.MethodPut, endpoint, request, &response, expected); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// RestoreSnapshot restores indices of a snapshot, and waits for the restore to
+// finish when wait is set.
+func RestoreSnapshot(ctx context.Context, repository, name string, request RestoreSnapshotRequest, wait bool) (*SnapshotResponse, error) {
+	endpoint := fmt.Sprintf("_snapshot/%s/%s/_restore?wait_for_completion=%t", url.PathEscape(repository), url.PathEscape(name), wait)
+
+	// Restores that are not waited for may be accepted with 202 instead of 200
+	expected := []int{http.StatusOK}
+	if !wait {
+		expected = append(expected, http.StatusAccepted)
+	}
+
+	var response SnapshotResponse
+	if err := httpRequest(ctx, http.MethodPost, endpoint, request, &response, expected...); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// RestoredIndices lists the indices of snapshot a restore request would
+// restore and the names they get, sorted by name. Like Elasticsearch it
+// renames every index matching the rename pattern.
+func RestoredIndices(snapshot Snapshot, request RestoreSnapshotRequest) ([]RestoredIndex, error) {
+	var rename *regexp.Regexp
+	var replacement string
+	if request.RenamePattern != "" {
+		var err error
+		if rename, err = regexp.Compile(request.RenamePattern); err != nil {
+			return nil, fmt.Errorf("invalid rename pattern: %w", err)
+		}
+		if replacement, err = javaReplacement(request.RenameReplacement, rename.NumSubexp()); err != nil {
+			return nil, fmt.Errorf("invalid rename replacement: %w", err)
+		}
+	}
+
+	restored := []RestoredIndex{}
+	for _, index := range snapshot.Indices {
+		if !matchIndexPatterns(index, request.Indices) {
+			continue
+		}
+		restoredAs := index
+		if rename != nil {
+			restoredAs = rename.ReplaceAllString(index, replacement)
+		}
+		restored = append(restored, RestoredIndex{Index: index, RestoredAs: restoredAs})
+	}
+	sort.Slice(restored, func(i, j int) bool {
+		return restored[i].Index < restored[j].Index
+	})
+	return restored, nil
+}
+
+// javaReplacement translates a replacement string in the syntax of Java's
+// String.replaceAll, which Elasticsearch renames indices with, to the syntax
+// of Go's regexp. Java reads '$12' as group 12 only when the pattern has that
+// many groups, and '$1_x' as group 1 followed by '_x', where Go would look
+// for a group named '1_x'. A backslash makes the next character literal.
+func javaReplacement(replacement string, groups int) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(replacement); i++ {
+		c := replacement[i]
+		switch {
+		case c == '\\':
+			i++
+			if i == len(replacement) {
+				return "", fmt.Errorf("character to be escaped is missing in %q", replacement)
+			}
+			if replacement[i] == '$' {
+				b.WriteString("$$")
+			} else {
+				b.WriteByte(replacement[i])
+			}
+		case c != '$':
+			b.WriteByte(c)
+		case i+1 < len(replacement) && replacement[i+1] == '{':
+			end := strings.IndexByte(replacement[i:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("named group reference is missing a '}' in %q", replacement)
+			}
+			b.WriteString(replacement[i : i+end+1])
+			i += end
+		case i+1 < len(replacement) && isDigit(replacement[i+1]):
+			i++
+			group := int(replacement[i] - '0')
+			for i+1 < len(replacement) && isDigit(replacement[i+1]) {
+				next := group*10 + int(replacement[i+1]-'0')
+				if next > groups {
+					break
+				}
+				group = next
+				i++
+			}
+			if group > groups {
+				return "", fmt.Errorf("no group %d in the rename pattern", group)
+			}
+			fmt.Fprintf(&b, "${%d}", group)
+		default:
+			return "", fmt.Errorf("illegal group reference in %q", replacement)
+		}
+	}
+	return b.String(), nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// matchIndexPatterns reports whether index matches a comma-separated list of
+// index patterns with '*' wildcards, where patterns starting with '-' exclude
+// indices again. An empty list matches every index.
+func matchIndexPatterns(index, patterns string) bool {
+	if patterns == "" {
+		return true
+	}
+
+	matched := false
+	for _, pattern := range strings.Split(patterns, ",") {
+		pattern = strings.TrimSpace(pattern)
+		exclude := strings.HasPrefix(pattern, "-")
+		pattern = strings.TrimPrefix(pattern, "-")
+		if pattern == "_all" {
+			pattern = "*"
+		}
+		if simpleMatch(pattern, index) {
+			matched = !exclude
+		}
+	}
+	return matched
+}
+
+// GetSnapshotProgress lists the shards of running snapshots and of indices
+// being restored from a snapshot, limited to repository when it is set.
+// Snapshot shards report the ID of their node, so it is resolved to the
+// node name.
+func GetSnapshotProgress(ctx context.Context, repository string) ([]ShardSnapshotProgress, error) {
+	endpoint := "_snapshot/_status"
+	if repository != "" {
+		endpoint = fmt.Sprintf("_snapshot/%s/_status", url.PathEscape(repository))
+	}
+	statuses, err := getSnapshotStatuses(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshot status: %w", err)
+	}
+
+	progress := []ShardSnapshotProgress{}
+	for _, status := range statuses {
+		for index, indexStatus := range status.Indices {
+			for shard, shardStatus := range indexStatus.Shards {
+				shardID, _ := strconv.Atoi(shard)
+				progress = append(progress, ShardSnapshotProgress{
+					Operation:  OperationSnapshot,
+					Repository: status.Repository,
+					Snapshot:   status.Snapshot,
+					Index:      index,
+					Shard:      shardID,
+					Stage:      shardStatus.Stage,
+					Node:       shardStatus.Node,
+					BytesTotal: shardStatus.Stats.Incremental.SizeInBytes,
+					BytesDone:  shardStatus.Stats.Processed.SizeInBytes,
+					FilesTotal: shardStatus.Stats.Incremental.FileCount,
+					FilesDone:  shardStatus.Stats.Processed.FileCount,
+				})
+			}
+		}
+	}
+
+	if len(progress) > 0 {
+		names, err := getNodeNames(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for i, shard := range progress {
+			if name, ok := names[shard.Node]; ok {
+				progress[i].Node = name
+			}
+		}
+	}
+
+	recoveries, err := GetRecoveries(ctx, "", true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recoveries: %w", err)
+	}
+	for _, recovery := range recoveries {
+		if recovery.Type != RecoveryTypeSnapshot || repository != "" && recovery.Source.Repository != repository {
+			continue
+		}
+		progress = append(progress, ShardSnapshotProgress{
+			Operation:  OperationRestore,
+			Repository: recovery.Source.Repository,
+			Snapshot:   recovery.Source.Snapshot,
+			Index:      recovery.Index,
+			Shard:      recovery.ID,
+			Stage:      recovery.Stage,
+			Node:       recovery.Target.Name,
+			BytesTotal: recovery.Files.TotalBytes,
+			BytesDone:  recovery.Files.RecoveredBytes + recovery.Files.ReusedBytes,
+			FilesTotal: recovery.Files.Total,
+			FilesDone:  recovery.Files.Recovered + recovery.Files.Reused,
+		})
+	}
+
+	sort.SliceStable(progress, func(i, j int) bool {
+		a, b := progress[i], progress[j]
+		if a.Snapshot != b.Snapshot {
+			return a.Snapshot < b.Snapshot
+		}
+		if a.Index != b.Index {
+			return a.Index < b.Index
+		}
+		return a.Shard < b.Shard
+	})
+	return progress, nil
+}
+
+func getSnapshotStatuses(ctx context.Context, endpoint string) ([]SnapshotStatus, error) {
+	var response struct {
+		Snapshots []SnapshotStatus `json:"snapshots"`
+	}
+	if err := getJSONResponse(ctx, endpoint, &response); err != nil {
+		return nil, err
+	}
+	return response.Snapshots, nil
+}
+
+// getNodeNames maps the IDs of the nodes to their names.
+func getNodeNames(ctx context.Context) (map[string]string, error) {
+	var response struct {
+		Nodes map[string]struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	}
+	if err := getJSONResponse(ctx, "_nodes?filter_path=nodes.*.name", &response); err != nil {
+		return nil, fmt.Errorf("failed to get nodes: %w", err)
+	}
+
+	names := make(map[string]string, len(response.Nodes))
+	for id, node := range response.Nodes {
+		names[id] = node.Name
+	}
+	return names, nil
+}
@@ -0,0 +1,171 @@
+package es
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/pincher95/esctl/internal/client"
+	"github.com/pincher95/esctl/shared"
+)
+
+// withServer points the shared client to a test server answering with handler.
+func withServer(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := client.NewClient(&client.Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous := shared.Client
+	shared.Client = c
+	t.Cleanup(func() { shared.Client = previous })
+}
+
+func TestRestoredIndices(t *testing.T) {
+	snapshot := Snapshot{Indices: []string{"logs-2", "metrics-1", "logs-1", ".kibana", "logs[a]"}}
+
+	tests := []struct {
+		name    string
+		request RestoreSnapshotRequest
+		want    []RestoredIndex
+	}{
+		{
+			name:    "all indices",
+			request: RestoreSnapshotRequest{},
+			want: []RestoredIndex{
+				{".kibana", ".kibana"}, {"logs-1", "logs-1"}, {"logs-2", "logs-2"}, {"logs[a]", "logs[a]"}, {"metrics-1", "metrics-1"},
+			},
+		},
+		{
+			name:    "patterns with exclusion",
+			request: RestoreSnapshotRequest{Indices: "logs-*,metrics-*,-logs-2"},
+			want:    []RestoredIndex{{"logs-1", "logs-1"}, {"metrics-1", "metrics-1"}},
+		},
+		{
+			name:    "brackets are not a wildcard",
+			request: RestoreSnapshotRequest{Indices: "logs[a]"},
+			want:    []RestoredIndex{{"logs[a]", "logs[a]"}},
+		},
+		{
+			name:    "renamed",
+			request: RestoreSnapshotRequest{Indices: "logs-*", RenamePattern: "logs-(.+)", RenameReplacement: "restored-logs-$1"},
+			want:    []RestoredIndex{{"logs-1", "restored-logs-1"}, {"logs-2", "restored-logs-2"}},
+		},
+		{
+			name:    "group followed by underscore",
+			request: RestoreSnapshotRequest{Indices: "logs-*", RenamePattern: "logs-(.+)", RenameReplacement: "$1_logs"},
+			want:    []RestoredIndex{{"logs-1", "1_logs"}, {"logs-2", "2_logs"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RestoredIndices(snapshot, tt.request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := RestoredIndices(snapshot, RestoreSnapshotRequest{RenamePattern: "("}); err == nil {
+		t.Error("expected an error for an invalid rename pattern")
+	}
+}
+
+func TestRepositoryLocation(t *testing.T) {
+	tests := []struct {
+		settings map[string]interface{}
+		want     string
+	}{
+		{map[string]interface{}{"location": "/mnt/backups"}, "/mnt/backups"},
+		{map[string]interface{}{"bucket": "backups", "base_path": "prod"}, "backups/prod"},
+		{map[string]interface{}{"container": "backups"}, "backups"},
+		{map[string]interface{}{}, ""},
+	}
+	for _, tt := range tests {
+		if got := (Repository{Settings: tt.settings}).Location(); got != tt.want {
+			t.Errorf("%v: got %q, want %q", tt.settings, got, tt.want)
+		}
+	}
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	tests := []struct {
+		name    string
+		wait    bool
+		status  int
+		wantErr bool
+	}{
+		{"accepted", false, http.StatusAccepted, false},
+		{"started", false, http.StatusOK, false},
+		{"completed", true, http.StatusOK, false},
+		{"accepted while waiting", true, http.StatusAccepted, true},
+		{"failed", false, http.StatusInternalServerError, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/_snapshot/backups/nightly/_restore" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{"accepted": true}`))
+			})
+
+			response, err := RestoreSnapshot(context.Background(), "backups", "nightly", RestoreSnapshotRequest{}, tt.wait)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !response.Accepted {
+				t.Errorf("got %+v, want accepted", response)
+			}
+		})
+	}
+}
+
+func TestJavaReplacement(t *testing.T) {
+	tests := []struct {
+		replacement string
+		groups      int
+		want        string
+		wantErr     bool
+	}{
+		{"restored-$1", 1, "restored-${1}", false},
+		{"$1_x", 1, "${1}_x", false},
+		{"$12", 1, "${1}2", false},
+		{"$12", 12, "${12}", false},
+		{"${name}-copy", 1, "${name}-copy", false},
+		{`cost\$1`, 1, "cost$$1", false},
+		{"$2", 1, "", true},
+		{"a$", 1, "", true},
+		{`a\`, 1, "", true},
+	}
+	for _, tt := range tests {
+		got, err := javaReplacement(tt.replacement, tt.groups)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("javaReplacement(%q) = %q, want an error", tt.replacement, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("javaReplacement(%q): %v", tt.replacement, err)
+		} else if got != tt.want {
+			t.Errorf("javaReplacement(%q) = %q, want %q", tt.replacement, got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package es
 
 import (
+	"context"
 	"net/url"
 	"strings"
 )
@@ -33,7 +34,7 @@ type Task struct {
 	Headers            map[string]interface{} `json:"headers"`
 }
 
-func GetTasks(actions []string) (TasksResponse, error) {
+func GetTasks(ctx context.Context, actions []string) (TasksResponse, error) {
 	baseEndpoint := "_tasks"
 
 	values := url.Values{
@@ -48,7 +49,7 @@ func GetTasks(actions []string) (TasksResponse, error) {
 	endpoint := baseEndpoint + "?" + values.Encode()
 
 	var response TasksResponse
-	if err := getJSONResponse(endpoint, &response); err != nil {
+	if err := getJSONResponse(ctx, endpoint, &response); err != nil {
 		return TasksResponse{}, err
 	}
 
@@ -1,93 +1,57 @@
 package es
 
 import (
-	"bytes"
-	"encoding/json"
-	"errors"
-	"fmt"
-	"io"
+	"context"
 	"net/http"
-	"os"
 	"strings"
 
+	"github.com/pincher95/esctl/internal/client"
 	"github.com/pincher95/esctl/shared"
 )
 
-type EsError struct {
-	Error struct {
-		Type   string `json:"type"`
-		Reason string `json:"reason"`
-	} `json:"error"`
-	Status int `json:"status"`
-}
-
-func debugLog(format string, args ...interface{}) {
-	if shared.Debug {
-		fmt.Fprintf(os.Stderr, "DEBUG: "+format+"\n", args...)
-	}
-}
-
-func httpRequest(method, endpoint string, body, target interface{}, expectedStatusCode int) error {
-	baseURL := fmt.Sprintf("%s://%s:%d/%s", shared.ElasticsearchProtocol, shared.ElasticsearchHost, shared.ElasticsearchPort, endpoint)
-
-	if shared.Debug {
-		debugLog("Request URL: %s", baseURL)
-	}
-
-	var bodyReader io.Reader
+// httpRequest sends a request and decodes the response into target. Any
+// status other than the expected ones is returned as a client.ResponseError.
+func httpRequest(ctx context.Context, method, endpoint string, body, target interface{}, expectedStatusCodes ...int) error {
+	req := shared.Client.R().SetContext(ctx).SetResult(target)
 	if body != nil {
-		bodyBytes, err := json.Marshal(body)
-		if err != nil {
-			return err
-		}
-		if shared.Debug {
-			debugLog("Request Body: %s", bodyBytes)
-		}
-		bodyReader = bytes.NewReader(bodyBytes)
-	}
-
-	req, err := http.NewRequest(method, baseURL, bodyReader)
-	if err != nil {
-		return err
-	}
-
-	if shared.ElasticsearchUsername != "" && shared.ElasticsearchPassword != "" {
-		req.SetBasicAuth(shared.ElasticsearchUsername, shared.ElasticsearchPassword)
+		req.SetBody(body)
 	}
 
-	req.Header.Add("Content-Type", "application/json")
-
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := req.Execute(method, endpoint)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != expectedStatusCode {
-		var esError EsError
-		if err := json.NewDecoder(resp.Body).Decode(&esError); err != nil {
-			return fmt.Errorf("unexpected http status: %s", resp.Status)
+	for _, code := range expectedStatusCodes {
+		if resp.StatusCode() == code {
+			return nil
 		}
-		return errors.New(esError.Error.Reason)
 	}
+	return client.NewResponseError(resp)
+}
+
+func getJSONResponse(ctx context.Context, endpoint string, target interface{}) error {
+	return httpRequest(ctx, http.MethodGet, endpoint, nil, target, http.StatusOK)
+}
 
-	return json.NewDecoder(resp.Body).Decode(target)
+func getJSONResponseWithBody(ctx context.Context, endpoint string, target interface{}, body interface{}) error {
+	return httpRequest(ctx, http.MethodGet, endpoint, body, target, http.StatusOK)
 }
 
-func getJSONResponse(endpoint string, target interface{}) error {
-	return httpRequest(http.MethodGet, endpoint, nil, target, http.StatusOK)
+func postJSONResponseWithBody(ctx context.Context, endpoint string, target interface{}, body interface{}) error {
+	return httpRequest(ctx, http.MethodPost, endpoint, body, target, http.StatusOK)
 }
 
-func getJSONResponseWithBody(endpoint string, target interface{}, body interface{}) error {
-	return httpRequest(http.MethodGet, endpoint, body, target, http.StatusOK)
+func postWithoutBody(ctx context.Context, endpoint string, target interface{}) error {
+	return httpRequest(ctx, http.MethodPost, endpoint, nil, target, http.StatusOK)
 }
 
-func postJSONResponseWithBody(endpoint string, target interface{}, body interface{}) error {
-	return httpRequest(http.MethodPost, endpoint, body, target, http.StatusOK)
+func putJSONResponseWithBody(ctx context.Context, endpoint string, target interface{}, body interface{}) error {
+	return httpRequest(ctx, http.MethodPut, endpoint, body, target, http.StatusOK)
 }
 
-func postWithoutBody(endpoint string, target interface{}) error {
-	return httpRequest(http.MethodPost, endpoint, nil, target, http.StatusOK)
+func deleteJSONResponse(ctx context.Context, endpoint string, target interface{}) error {
+	return httpRequest(ctx, http.MethodDelete, endpoint, nil, target, http.StatusOK)
 }
 
 func getNestedPath(field string, nestedPaths []string) (string, bool) {
@@ -99,9 +63,45 @@ func getNestedPath(field string, nestedPaths []string) (string, bool) {
 	return "", false
 }
 
+func intValue(i *int) int {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
 	}
 	return b
 }
+
+// simpleMatch matches s against a pattern where '*' stands for any sequence
+// of characters, the only wildcard Elasticsearch knows in index and template
+// patterns.
+func simpleMatch(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return strings.HasSuffix(s, parts[len(parts)-1])
+}
@@ -0,0 +1,26 @@
+package es
+
+import "testing"
+
+func TestSimpleMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		s       string
+		want    bool
+	}{
+		{"logs-*", "logs-2024.05.01", true},
+		{"logs-*", "metrics-1", false},
+		{"*", "anything", true},
+		{"logs", "logs", true},
+		{"logs", "logs-1", false},
+		{"*-app-*", "logs-app-1", true},
+		{"*-app-*", "logs-app", false},
+		{"a*b*b", "abb", true},
+		{"a*b*b", "ab", false},
+	}
+	for _, tt := range tests {
+		if got := simpleMatch(tt.pattern, tt.s); got != tt.want {
+			t.Errorf("simpleMatch(%q, %q) = %t, want %t", tt.pattern, tt.s, got, tt.want)
+		}
+	}
+}
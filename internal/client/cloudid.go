@@ -0,0 +1,55 @@
+package client
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+const defaultCloudPort = 443
+
+// CloudEndpoint is the Elasticsearch endpoint encoded in an Elastic Cloud ID.
+type CloudEndpoint struct {
+	Protocol string
+	Host     string
+	Port     int
+}
+
+// DecodeCloudID decodes an Elastic Cloud ID of the form
+// 'name:base64(domain[:port]$es-uuid$kibana-uuid)' into the Elasticsearch endpoint.
+func DecodeCloudID(cloudID string) (*CloudEndpoint, error) {
+	encoded := cloudID
+	if idx := strings.LastIndex(cloudID, ":"); idx != -1 {
+		encoded = cloudID[idx+1:]
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		decoded, err = base64.RawStdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cloud id: %w", err)
+		}
+	}
+
+	parts := strings.Split(string(decoded), "$")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid cloud id: expected domain and elasticsearch id")
+	}
+
+	domain, port := parts[0], defaultCloudPort
+	if host, portStr, err := net.SplitHostPort(domain); err == nil {
+		parsedPort, err := strconv.Atoi(portStr)
+		if err != nil || parsedPort <= 0 {
+			return nil, fmt.Errorf("invalid cloud id: bad port %q", portStr)
+		}
+		domain, port = host, parsedPort
+	}
+
+	return &CloudEndpoint{
+		Protocol: "https",
+		Host:     parts[1] + "." + domain,
+		Port:     port,
+	}, nil
+}
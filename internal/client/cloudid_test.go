@@ -0,0 +1,58 @@
+package client
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestDecodeCloudID(t *testing.T) {
+	encode := func(s string) string {
+		return base64.StdEncoding.EncodeToString([]byte(s))
+	}
+
+	tests := []struct {
+		name        string
+		input       string
+		expected    CloudEndpoint
+		expectError bool
+	}{
+		{
+			"Default port",
+			"prod:" + encode("us-east-1.aws.found.io$abc123$def456"),
+			CloudEndpoint{Protocol: "https", Host: "abc123.us-east-1.aws.found.io", Port: 443},
+			false,
+		},
+		{
+			"Explicit port",
+			"staging:" + encode("europe-west1.gcp.cloud.es.io:9243$abc123$def456"),
+			CloudEndpoint{Protocol: "https", Host: "abc123.europe-west1.gcp.cloud.es.io", Port: 9243},
+			false,
+		},
+		{
+			"Without name",
+			encode("us-east-1.aws.found.io$abc123"),
+			CloudEndpoint{Protocol: "https", Host: "abc123.us-east-1.aws.found.io", Port: 443},
+			false,
+		},
+		{"Missing elasticsearch id", "prod:" + encode("us-east-1.aws.found.io"), CloudEndpoint{}, true},
+		{"Not base64", "prod:not-base64!", CloudEndpoint{}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			endpoint, err := DecodeCloudID(tc.input)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error for %s, got %+v", tc.input, endpoint)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *endpoint != tc.expected {
+				t.Errorf("DecodeCloudID(%s) = %+v, want %+v", tc.input, *endpoint, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// ResponseError is returned when Elasticsearch answers with an unexpected
+// status code. It marshals back to the error envelope Elasticsearch sent.
+type ResponseError struct {
+	// Cause is the error reported by Elasticsearch, empty if the body was not an error envelope
+	Cause      ErrorCause `json:"error"`
+	StatusCode int        `json:"status"`
+	Status     string     `json:"-"`
+}
+
+// ErrorCause is an error in the Elasticsearch error envelope, e.g.
+// {"type":"index_not_found_exception","reason":"no such index [logs]","index":"logs"}.
+type ErrorCause struct {
+	Type      string       `json:"type,omitempty"`
+	Reason    string       `json:"reason,omitempty"`
+	Index     string       `json:"index,omitempty"`
+	Shard     json.Number  `json:"shard,omitempty"`
+	RootCause []ErrorCause `json:"root_cause,omitempty"`
+	CausedBy  *ErrorCause  `json:"caused_by,omitempty"`
+}
+
+// UnmarshalJSON also accepts the plain string some endpoints return as error,
+// e.g. for an unsupported HTTP method.
+func (c *ErrorCause) UnmarshalJSON(data []byte) error {
+	var reason string
+	if err := json.Unmarshal(data, &reason); err == nil {
+		*c = ErrorCause{Reason: reason}
+		return nil
+	}
+
+	type plain ErrorCause
+	return json.Unmarshal(data, (*plain)(c))
+}
+
+func (c ErrorCause) String() string {
+	if c.Type == "" {
+		return c.Reason
+	}
+	return fmt.Sprintf("%s: %s", c.Type, c.Reason)
+}
+
+func (e *ResponseError) Error() string {
+	if e.Cause.Reason == "" {
+		return fmt.Sprintf("unexpected http status: %s", e.Status)
+	}
+	return e.Cause.String()
+}
+
+// NotFound reports whether the requested resource, e.g. an index, does not exist.
+func (e *ResponseError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// NewResponseError builds a ResponseError from an Elasticsearch error response.
+func NewResponseError(resp *resty.Response) *ResponseError {
+	respErr := &ResponseError{
+		StatusCode: resp.StatusCode(),
+		Status:     resp.Status(),
+	}
+
+	var envelope struct {
+		Error ErrorCause `json:"error"`
+	}
+	if err := json.Unmarshal(resp.Body(), &envelope); err == nil {
+		respErr.Cause = envelope.Error
+	}
+
+	return respErr
+}
+
+// NotFoundError is returned when Elasticsearch answers successfully but the
+// requested entity, e.g. a node, does not exist.
+type NotFoundError struct {
+	Kind string
+	Name string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s not found: %s", e.Kind, e.Name)
+}
+
+// IsConnectionError reports whether err means Elasticsearch could not be
+// reached at all, as opposed to answering with an error.
+func IsConnectionError(err error) bool {
+	var urlErr *url.Error
+	var netErr net.Error
+	return errors.As(err, &urlErr) || errors.As(err, &netErr)
+}
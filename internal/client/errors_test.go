@@ -0,0 +1,78 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewResponseError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"type":"index_not_found_exception","reason":"no such index [logs]"},"status":404}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(&Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := c.R().Get("/logs/_mapping")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	respErr := NewResponseError(resp)
+	if !respErr.NotFound() {
+		t.Errorf("expected a not found error, got status %d", respErr.StatusCode)
+	}
+	if respErr.Error() != "index_not_found_exception: no such index [logs]" {
+		t.Errorf("unexpected message %q", respErr.Error())
+	}
+}
+
+func TestErrorCauseUnmarshal(t *testing.T) {
+	body := `{
+		"type": "search_phase_execution_exception",
+		"reason": "all shards failed",
+		"root_cause": [{"type": "query_shard_exception", "reason": "failed to create query", "index": "logs", "shard": 0}],
+		"caused_by": {"type": "illegal_argument_exception", "reason": "bad field", "caused_by": {"type": "number_format_exception", "reason": "For input string: \"x\""}}
+	}`
+
+	var cause ErrorCause
+	if err := json.Unmarshal([]byte(body), &cause); err != nil {
+		t.Fatal(err)
+	}
+
+	if cause.String() != "search_phase_execution_exception: all shards failed" {
+		t.Errorf("unexpected cause %q", cause)
+	}
+	if len(cause.RootCause) != 1 || cause.RootCause[0].Index != "logs" || cause.RootCause[0].Shard != "0" {
+		t.Errorf("unexpected root cause %+v", cause.RootCause)
+	}
+	if cause.CausedBy == nil || cause.CausedBy.CausedBy == nil || cause.CausedBy.CausedBy.Type != "number_format_exception" {
+		t.Errorf("caused_by chain not parsed: %+v", cause.CausedBy)
+	}
+
+	// Some endpoints report the error as a plain string
+	if err := json.Unmarshal([]byte(`"Incorrect HTTP method for uri [/_cat/indices]"`), &cause); err != nil {
+		t.Fatal(err)
+	}
+	if cause.Reason != "Incorrect HTTP method for uri [/_cat/indices]" || cause.Type != "" {
+		t.Errorf("unexpected cause %+v", cause)
+	}
+}
+
+func TestIsConnectionError(t *testing.T) {
+	c, err := NewClient(&Config{BaseURL: deadHost(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.R().Get("/")
+	if !IsConnectionError(err) {
+		t.Errorf("expected a connection error, got %v", err)
+	}
+}
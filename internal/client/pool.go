@@ -0,0 +1,180 @@
+package client
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	HostSelectionFailover   = "failover"
+	HostSelectionRoundRobin = "round-robin"
+)
+
+const (
+	deadNodeInitialTimeout = 30 * time.Second
+	deadNodeMaxTimeout     = 10 * time.Minute
+)
+
+type poolNode struct {
+	url       *url.URL
+	failures  int
+	deadUntil time.Time
+}
+
+// nodePool is an http.RoundTripper that spreads requests over several
+// cluster nodes. Nodes that fail to connect are marked dead for a while, and
+// idempotent requests are retried on the next node.
+type nodePool struct {
+	transport  http.RoundTripper
+	nodes      []*poolNode
+	roundRobin bool
+	debug      bool
+
+	// basePath is the path prefix of the first host, which requests are
+	// built against
+	basePath string
+
+	mu   sync.Mutex
+	next int
+}
+
+func newNodePool(transport http.RoundTripper, hosts []string, selection string, debug bool) (*nodePool, error) {
+	pool := &nodePool{
+		transport: transport,
+		debug:     debug,
+	}
+
+	switch selection {
+	case "", HostSelectionFailover:
+	case HostSelectionRoundRobin:
+		pool.roundRobin = true
+	default:
+		return nil, fmt.Errorf("unknown host selection %q, expected %s or %s", selection, HostSelectionFailover, HostSelectionRoundRobin)
+	}
+
+	for _, host := range hosts {
+		u, err := url.Parse(host)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid host URL %q", host)
+		}
+		pool.nodes = append(pool.nodes, &poolNode{url: u})
+	}
+	if len(pool.nodes) > 0 {
+		pool.basePath = strings.TrimRight(pool.nodes[0].url.Path, "/")
+	}
+
+	if pool.roundRobin {
+		// Start at a random node so consecutive invocations spread the load
+		pool.next = rand.Intn(len(pool.nodes))
+	}
+
+	return pool, nil
+}
+
+// candidates returns the nodes in the order they should be tried: live nodes
+// first, starting at the round-robin position, then dead nodes as a last resort.
+func (p *nodePool) candidates() []*poolNode {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	start := 0
+	if p.roundRobin {
+		start = p.next
+		p.next = (p.next + 1) % len(p.nodes)
+	}
+
+	now := time.Now()
+	live := make([]*poolNode, 0, len(p.nodes))
+	var dead []*poolNode
+	for i := range p.nodes {
+		n := p.nodes[(start+i)%len(p.nodes)]
+		if n.deadUntil.After(now) {
+			dead = append(dead, n)
+		} else {
+			live = append(live, n)
+		}
+	}
+
+	return append(live, dead...)
+}
+
+// path moves a request path from the base path prefix to the node's own
+// prefix, e.g. '/es/_cat/nodes' to '/search/_cat/nodes' for a node behind
+// 'https://proxy/search'.
+func (n *poolNode) path(path, basePath string) string {
+	return strings.TrimRight(n.url.Path, "/") + strings.TrimPrefix(path, basePath)
+}
+
+func (p *nodePool) markDead(n *poolNode) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	timeout := deadNodeInitialTimeout << n.failures
+	if timeout <= 0 || timeout > deadNodeMaxTimeout {
+		timeout = deadNodeMaxTimeout
+	}
+	n.failures++
+	n.deadUntil = time.Now().Add(timeout)
+}
+
+func (p *nodePool) markAlive(n *poolNode) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	n.failures = 0
+	n.deadUntil = time.Time{}
+}
+
+func (p *nodePool) RoundTrip(req *http.Request) (*http.Response, error) {
+	var lastErr error
+
+	for attempt, n := range p.candidates() {
+		if attempt > 0 {
+			if !isIdempotentMethod(req.Method) || req.Context().Err() != nil {
+				break
+			}
+			if req.Body != nil && req.GetBody == nil {
+				break
+			}
+		}
+
+		r := req.Clone(req.Context())
+		r.URL.Scheme = n.url.Scheme
+		r.URL.Host = n.url.Host
+		r.URL.Path = n.path(req.URL.Path, p.basePath)
+		if req.URL.RawPath != "" {
+			r.URL.RawPath = n.path(req.URL.RawPath, p.basePath)
+		}
+		r.Host = ""
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r.Body = body
+		}
+
+		resp, err := p.transport.RoundTrip(r)
+		if err == nil {
+			p.markAlive(n)
+			return resp, nil
+		}
+
+		lastErr = err
+		if req.Context().Err() != nil {
+			break
+		}
+		p.markDead(n)
+		if p.debug {
+			fmt.Fprintf(os.Stderr, "DEBUG: node %s marked dead: %v\n", n.url.Host, err)
+		}
+	}
+
+	return nil, lastErr
+}
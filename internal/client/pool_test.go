@@ -0,0 +1,127 @@
+package client
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func deadHost(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	return "http://" + addr
+}
+
+func TestNodePoolFailover(t *testing.T) {
+	live := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer live.Close()
+
+	dead := deadHost(t)
+
+	pool, err := newNodePool(http.DefaultTransport, []string{dead, live.URL}, HostSelectionFailover, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, dead+"/_cat/nodes", nil)
+	resp, err := pool.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("expected GET to fail over to the live node, got %v", err)
+	}
+	resp.Body.Close()
+
+	if pool.nodes[0].deadUntil.IsZero() {
+		t.Errorf("expected %s to be marked dead", dead)
+	}
+
+	candidates := pool.candidates()
+	if candidates[0].url.String() != live.URL {
+		t.Errorf("expected live node to be tried first, got %s", candidates[0].url)
+	}
+}
+
+func TestNodePoolDoesNotRetryWrites(t *testing.T) {
+	var calls int
+	live := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer live.Close()
+
+	pool, err := newNodePool(http.DefaultTransport, []string{deadHost(t), live.URL}, HostSelectionFailover, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, live.URL+"/_cluster/reroute", nil)
+	if _, err := pool.RoundTrip(req); err == nil {
+		t.Errorf("expected POST to fail without retrying on the next node")
+	}
+
+	if calls != 0 {
+		t.Errorf("expected no request to reach the live node, got %d", calls)
+	}
+}
+
+func TestNodePoolRoundRobin(t *testing.T) {
+	pool, err := newNodePool(http.DefaultTransport, []string{"http://a:9200", "http://b:9200", "http://c:9200"}, HostSelectionRoundRobin, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seen := make(map[string]bool)
+	for range pool.nodes {
+		seen[pool.candidates()[0].url.Host] = true
+	}
+
+	if len(seen) != len(pool.nodes) {
+		t.Errorf("expected every node to be first once, got %v", seen)
+	}
+}
+
+func TestNewNodePoolInvalid(t *testing.T) {
+	if _, err := newNodePool(http.DefaultTransport, []string{"http://a:9200"}, "random", false); err == nil {
+		t.Errorf("expected error for unknown host selection")
+	}
+
+	if _, err := newNodePool(http.DefaultTransport, []string{"a:9200"}, HostSelectionFailover, false); err == nil {
+		t.Errorf("expected error for host without scheme")
+	}
+}
+
+func TestNodePoolKeepsPathPrefix(t *testing.T) {
+	var path string
+	live := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer live.Close()
+
+	dead := deadHost(t)
+
+	pool, err := newNodePool(http.DefaultTransport, []string{dead + "/es/", live.URL + "/search"}, HostSelectionFailover, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, dead+"/es/logs-1,100%25/_alias", nil)
+	resp, err := pool.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("expected GET to fail over to the live node, got %v", err)
+	}
+	resp.Body.Close()
+
+	if want := "/search/logs-1,100%25/_alias"; path != want {
+		t.Errorf("got path %s, want %s", path, want)
+	}
+}
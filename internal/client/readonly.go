@@ -0,0 +1,113 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// ReadOnlyError is returned for requests that could change a cluster when
+// the context is read-only.
+type ReadOnlyError struct {
+	// Request is the refused request, e.g. 'DELETE logs-1', empty when a command checks up front
+	Request string
+}
+
+func (e *ReadOnlyError) Error() string {
+	if e.Request == "" {
+		return "the context is read-only, pass --allow-writes to change the cluster"
+	}
+	return fmt.Sprintf("refusing %s, the context is read-only, pass --allow-writes to change the cluster", e.Request)
+}
+
+// readOnlyAPIs are the APIs only reading data even though they are sent with
+// POST, usually because they take a body. They match the trailing path of a
+// request, e.g. 'logs-*/_search' or '_search/scroll'.
+var readOnlyAPIs = []string{
+	"_search",
+	"_msearch",
+	"_count",
+	"_field_caps",
+	"_mget",
+	"_termvectors",
+	"_mtermvectors",
+	"_explain",
+	"_validate/query",
+	"_render/template",
+	"_analyze",
+	"_sql",
+	"_eql/search",
+	"_query",
+	"_async_search",
+	"_cluster/allocation/explain",
+	"_security/user/_has_privileges",
+	// Refreshing only makes recent writes visible, e.g. before counting
+	"_refresh",
+}
+
+// dryRunAPIs only evaluate a request when asked for a dry run.
+var dryRunAPIs = []string{
+	"_cluster/reroute",
+	"_rollover",
+}
+
+// IsReadRequest reports whether a request only reads from the cluster. The
+// path is relative to the cluster, with or without the query string.
+func IsReadRequest(method, path string) bool {
+	if method == http.MethodGet || method == http.MethodHead {
+		return true
+	}
+	if method != http.MethodPost {
+		return false
+	}
+
+	u, err := url.Parse(path)
+	if err != nil {
+		return false
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	for i, segment := range segments {
+		// Simulating a template or pipeline never stores anything
+		if segment == "_simulate" || segment == "_simulate_index" {
+			return true
+		}
+
+		api := strings.Join(segments[i:], "/")
+		for _, readOnly := range readOnlyAPIs {
+			if api == readOnly || strings.HasPrefix(api, readOnly+"/") {
+				return true
+			}
+		}
+		if u.Query().Get("dry_run") == "true" {
+			for _, dryRun := range dryRunAPIs {
+				if api == dryRun || strings.HasPrefix(api, dryRun+"/") {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// refuseWrites is a request middleware failing every request that could
+// change the cluster, so no command can bypass a read-only context.
+func refuseWrites(_ *resty.Client, req *resty.Request) error {
+	path := req.URL
+	if len(req.QueryParam) > 0 {
+		separator := "?"
+		if strings.Contains(path, "?") {
+			separator = "&"
+		}
+		path += separator + req.QueryParam.Encode()
+	}
+
+	if IsReadRequest(req.Method, path) {
+		return nil
+	}
+	endpoint, _, _ := strings.Cut(strings.TrimPrefix(req.URL, "/"), "?")
+	return &ReadOnlyError{Request: req.Method + " " + endpoint}
+}
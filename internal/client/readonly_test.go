@@ -0,0 +1,76 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestIsReadRequest(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   bool
+	}{
+		{http.MethodGet, "_cat/indices?format=json", true},
+		{http.MethodHead, "logs", true},
+		{http.MethodPost, "logs-*/_search", true},
+		{http.MethodPost, "_all/_search", true},
+		{http.MethodPost, "_search/scroll", true},
+		{http.MethodPost, "logs/_count", true},
+		{http.MethodPost, "logs/_explain/1", true},
+		{http.MethodPost, "_index_template/_simulate_index/logs-1", true},
+		{http.MethodPost, "_ingest/pipeline/my-pipeline/_simulate", true},
+		{http.MethodPost, "_cluster/reroute?dry_run=true&explain=true", true},
+		{http.MethodPost, "logs-write/_rollover?dry_run=true", true},
+		{http.MethodPost, "_cluster/reroute?format=json&explain=true", false},
+		{http.MethodPost, "logs-write/_rollover", false},
+		{http.MethodPost, "logs/_close", false},
+		{http.MethodPost, "logs/_delete_by_query", false},
+		{http.MethodPost, "logs/_update_by_query", false},
+		{http.MethodPut, "logs", false},
+		{http.MethodDelete, "_search/scroll", false},
+		{http.MethodDelete, "logs", false},
+	}
+
+	for _, test := range tests {
+		if got := IsReadRequest(test.method, test.path); got != test.want {
+			t.Errorf("%s %s: got %v, want %v", test.method, test.path, got, test.want)
+		}
+	}
+}
+
+func TestReadOnlyClient(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c, err := NewClient(&Config{BaseURL: server.URL, ReadOnly: true, RetryCount: 3, RetryWaitTime: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.ReadOnly() {
+		t.Error("expected a read-only client")
+	}
+
+	if _, err := c.R().SetQueryParam("dry_run", "true").Post("/_cluster/reroute"); err != nil {
+		t.Errorf("dry run reroute: %v", err)
+	}
+
+	_, err = c.R().Delete("/logs-1,logs-2")
+	var readOnlyErr *ReadOnlyError
+	if !errors.As(err, &readOnlyErr) {
+		t.Fatalf("expected a ReadOnlyError, got %v", err)
+	}
+	if readOnlyErr.Request != "DELETE logs-1,logs-2" {
+		t.Errorf("got refused request %q", readOnlyErr.Request)
+	}
+	if calls != 1 {
+		t.Errorf("expected only the dry run to reach the cluster, got %d calls", calls)
+	}
+}
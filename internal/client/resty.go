@@ -1,6 +1,8 @@
 package client
 
 import (
+	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/go-resty/resty/v2"
@@ -12,17 +14,30 @@ type Config struct {
 	RetryWaitTime time.Duration
 	Timeout       time.Duration
 	BaseURL       string
+	Hosts         []string
+	HostSelection string
+	Proxy         string
+	Headers       map[string]string
+	OpaqueID      string
+	Username      string
+	Password      string
+	APIKey        string
+	BearerToken   string
+	TLS           TLSConfig
 	RetryCount    int
 	Debug         bool
+	// ReadOnly refuses every request that could change the cluster
+	ReadOnly bool
 }
 
 // Client is a wrapper around *resty.Client that you can customize.
 type Client struct {
 	*resty.Client
+	readOnly bool
 }
 
 // NewClient returns a configured resty client based on the given Config.
-func NewClient(cfg *Config) *Client {
+func NewClient(cfg *Config) (*Client, error) {
 	r := resty.New()
 
 	// Set base URL if provided
@@ -30,20 +45,98 @@ func NewClient(cfg *Config) *Client {
 		r.SetBaseURL(cfg.BaseURL)
 	}
 
+	// Elasticsearch speaks JSON only, and a few read APIs accept a body on GET
+	r.SetHeader("Content-Type", "application/json")
+	r.SetHeader("Accept", "application/json")
+	r.SetAllowGetMethodPayload(true)
+
+	// Tag requests so they can be traced in slow logs and the tasks API,
+	// unless the caller sets its own X-Opaque-Id
+	if cfg.OpaqueID != "" {
+		r.SetHeader("X-Opaque-Id", cfg.OpaqueID)
+	}
+	for key, value := range cfg.Headers {
+		r.SetHeader(key, value)
+	}
+
+	// Plain-http clusters with basic auth are common on private networks
+	r.SetDisableWarn(true)
+
+	// Set authentication, an API key wins over a bearer token which wins over basic auth
+	switch {
+	case cfg.APIKey != "":
+		r.SetAuthScheme("ApiKey").SetAuthToken(cfg.APIKey)
+	case cfg.BearerToken != "":
+		r.SetAuthToken(cfg.BearerToken)
+	case cfg.Username != "" && cfg.Password != "":
+		r.SetBasicAuth(cfg.Username, cfg.Password)
+	}
+
+	// Set TLS options if any were provided
+	if !cfg.TLS.isZero() {
+		tlsConfig, err := newTLSConfig(cfg.TLS)
+		if err != nil {
+			return nil, err
+		}
+		r.SetTLSClientConfig(tlsConfig)
+	}
+
+	// Route requests through a proxy if configured
+	if cfg.Proxy != "" {
+		proxyURL, err := url.Parse(cfg.Proxy)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", cfg.Proxy)
+		}
+		r.SetProxy(proxyURL.String())
+	}
+
 	// Set request timeout
 	r.SetTimeout(cfg.Timeout)
 
-	// Enable debug if needed
+	// Enable debug if needed, without leaking credentials into the log
 	r.SetDebug(cfg.Debug)
+	r.OnRequestLog(redactRequestLog)
+	if !cfg.Debug {
+		// Failed requests are reported by the caller, retry attempts only in debug mode
+		r.SetLogger(quietLogger{})
+	}
 
-	// Set retry count and wait time
+	if cfg.ReadOnly {
+		r.OnBeforeRequest(refuseWrites)
+	}
+
+	// Retry with exponential backoff on connection errors and on responses
+	// telling us the cluster is overloaded or temporarily unavailable
 	if cfg.RetryCount > 0 {
 		r.
 			SetRetryCount(cfg.RetryCount).
-			SetRetryWaitTime(cfg.RetryWaitTime)
+			SetRetryWaitTime(cfg.RetryWaitTime).
+			SetRetryMaxWaitTime(maxRetryWaitTime).
+			SetRetryAfter(retryAfter).
+			AddRetryCondition(shouldRetry)
+	}
+
+	// Spread requests over several nodes when more than one is configured.
+	// This wraps the transport, so it must come after any transport settings.
+	if len(cfg.Hosts) > 1 {
+		transport, err := r.Transport()
+		if err != nil {
+			return nil, err
+		}
+		pool, err := newNodePool(transport, cfg.Hosts, cfg.HostSelection, cfg.Debug)
+		if err != nil {
+			return nil, err
+		}
+		r.SetTransport(pool)
 	}
 
-	return &Client{r}
+	return &Client{Client: r, readOnly: cfg.ReadOnly}, nil
+}
+
+// ReadOnly reports whether requests that could change the cluster are refused,
+// so commands can fail before asking for confirmation.
+func (c *Client) ReadOnly() bool {
+	return c.readOnly
 }
 
 func (c *Client) WithAuthToken(token string) *Client {
@@ -55,3 +148,18 @@ func (c *Client) WithHeader(key, value string) *Client {
 	c.SetHeader(key, value)
 	return c
 }
+
+// quietLogger discards resty's own warnings and errors.
+type quietLogger struct{}
+
+func (quietLogger) Errorf(string, ...interface{}) {}
+func (quietLogger) Warnf(string, ...interface{})  {}
+func (quietLogger) Debugf(string, ...interface{}) {}
+
+// redactRequestLog masks the Authorization header in debug output.
+func redactRequestLog(rl *resty.RequestLog) error {
+	if rl.Header.Get("Authorization") != "" {
+		rl.Header.Set("Authorization", "<redacted>")
+	}
+	return nil
+}
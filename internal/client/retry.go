@@ -0,0 +1,43 @@
+package client
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const maxRetryWaitTime = 30 * time.Second
+
+// shouldRetry retries responses that signal a busy or restarting cluster, and
+// connection errors of requests that are safe to send twice.
+func shouldRetry(resp *resty.Response, err error) bool {
+	if err != nil {
+		return resp != nil && resp.Request != nil && isIdempotentMethod(resp.Request.Method)
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter honours a Retry-After header given in seconds, falling back to
+// exponential backoff when there is none.
+func retryAfter(_ *resty.Client, resp *resty.Response) (time.Duration, error) {
+	if resp == nil || resp.RawResponse == nil {
+		return 0, nil
+	}
+
+	seconds, err := strconv.Atoi(resp.Header().Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0, nil
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+func isIdempotentMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
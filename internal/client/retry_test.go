@@ -0,0 +1,70 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryOnUnavailable(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c, err := NewClient(&Config{BaseURL: server.URL, RetryCount: 3, RetryWaitTime: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := c.R().Get("/_cluster/health")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode() != http.StatusOK || calls != 3 {
+		t.Errorf("expected success after 3 calls, got status %d after %d calls", resp.StatusCode(), calls)
+	}
+}
+
+func TestNoRetryOnClientError(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	c, err := NewClient(&Config{BaseURL: server.URL, RetryCount: 3, RetryWaitTime: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.R().Get("/missing"); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("expected a single call for 404, got %d", calls)
+	}
+}
+
+func TestNoRetryOfWritesOnConnectionError(t *testing.T) {
+	c, err := NewClient(&Config{BaseURL: deadHost(t), RetryCount: 3, RetryWaitTime: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := c.R().Post("/_cluster/reroute")
+	if err == nil {
+		t.Fatal("expected connection error")
+	}
+	if resp.Request.Attempt != 1 {
+		t.Errorf("expected POST not to be retried, got %d attempts", resp.Request.Attempt)
+	}
+}
@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"context"
+	"io"
+	"unicode/utf8"
+)
+
+// key is a rune typed by the user, or one of the special keys below.
+type key rune
+
+const (
+	keyCtrlC     key = 3
+	keyEnter     key = '\r'
+	keyEscape    key = 27
+	keyBackspace key = 127
+
+	// Special keys are mapped to the Unicode private use area
+	keyUp key = 0xE000 + iota
+	keyDown
+	keyLeft
+	keyRight
+	keyPageUp
+	keyPageDown
+	keyHome
+	keyEnd
+)
+
+// escapeSequences maps the ANSI sequences sent by cursor keys, without the
+// leading escape, to keys.
+var escapeSequences = map[string]key{
+	"[A":  keyUp,
+	"[B":  keyDown,
+	"[C":  keyRight,
+	"[D":  keyLeft,
+	"[5~": keyPageUp,
+	"[6~": keyPageDown,
+	"[H":  keyHome,
+	"[F":  keyEnd,
+	"[1~": keyHome,
+	"[4~": keyEnd,
+	"OA":  keyUp,
+	"OB":  keyDown,
+	"OC":  keyRight,
+	"OD":  keyLeft,
+	"OH":  keyHome,
+	"OF":  keyEnd,
+}
+
+func (k key) isPrintable() bool {
+	return k >= ' ' && k != keyBackspace && k < keyUp
+}
+
+// readKeys decodes key presses from r until it fails or ctx is done.
+func readKeys(ctx context.Context, r io.Reader, keys chan<- key) {
+	buf := make([]byte, 64)
+	for {
+		n, err := r.Read(buf)
+		if err != nil {
+			return
+		}
+
+		for _, k := range parseKeys(buf[:n]) {
+			select {
+			case keys <- k:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// parseKeys splits one read from the terminal into keys. A lone escape is
+// the Escape key, otherwise it starts a cursor key sequence.
+func parseKeys(data []byte) []key {
+	var keys []key
+	for len(data) > 0 {
+		if data[0] == byte(keyEscape) {
+			if len(data) == 1 {
+				keys = append(keys, keyEscape)
+				break
+			}
+			matched := false
+			for sequence, k := range escapeSequences {
+				if len(data) > len(sequence) && string(data[1:1+len(sequence)]) == sequence {
+					keys = append(keys, k)
+					data = data[1+len(sequence):]
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				// Unknown sequence, treat the escape on its own
+				keys = append(keys, keyEscape)
+				data = data[1:]
+			}
+			continue
+		}
+
+		if data[0] == 8 {
+			// Some terminals send Ctrl-H for backspace
+			keys = append(keys, keyBackspace)
+			data = data[1:]
+			continue
+		}
+
+		r, size := utf8.DecodeRune(data)
+		keys = append(keys, key(r))
+		data = data[size:]
+	}
+	return keys
+}
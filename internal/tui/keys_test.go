@@ -0,0 +1,34 @@
+package tui
+
+import "testing"
+
+func TestParseKeys(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected []key
+	}{
+		{"Printable", "q/", []key{'q', '/'}},
+		{"Cursor keys", "\x1b[A\x1b[B\x1b[C\x1b[D", []key{keyUp, keyDown, keyRight, keyLeft}},
+		{"Application mode cursor keys", "\x1bOA", []key{keyUp}},
+		{"Paging", "\x1b[5~\x1b[6~", []key{keyPageUp, keyPageDown}},
+		{"Lone escape", "\x1b", []key{keyEscape}},
+		{"Control keys", "\x03\r\x7f\x08", []key{keyCtrlC, keyEnter, keyBackspace, keyBackspace}},
+		{"Unicode", "ü", []key{'ü'}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			keys := parseKeys([]byte(tc.input))
+			if len(keys) != len(tc.expected) {
+				t.Fatalf("got %v, want %v", keys, tc.expected)
+			}
+			for i := range keys {
+				if keys[i] != tc.expected[i] {
+					t.Errorf("got %v, want %v", keys, tc.expected)
+					break
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,471 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pincher95/esctl/output"
+	"golang.org/x/term"
+)
+
+const (
+	enterAltScreen = "\033[?1049h"
+	exitAltScreen  = "\033[?1049l"
+	hideCursor     = "\033[?25l"
+	showCursor     = "\033[?25h"
+	moveHome       = "\033[H"
+	clearLine      = "\033[K"
+	clearBelow     = "\033[J"
+	styleReset     = "\033[0m"
+	styleHeader    = "\033[7m"
+	styleChanged   = "\033[1;33m"
+	styleDim       = "\033[2m"
+	styleError     = "\033[1;31m"
+)
+
+// Fetch retrieves a fresh table on every refresh. ctx is cancelled when the
+// view closes, so a slow request does not outlive it.
+type Fetch func(ctx context.Context) (*output.Table, error)
+
+// Options configures a watch view.
+type Options struct {
+	// Title is shown in the status line, e.g. 'esctl get indices'
+	Title    string
+	Interval time.Duration
+	// SortBy is the initial sort order in --sort-by syntax
+	SortBy string
+}
+
+// IsTerminal reports whether both stdin and stdout are terminals, which the
+// watch view needs for reading keys and drawing.
+func IsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+type fetchResult struct {
+	table *output.Table
+	err   error
+	at    time.Time
+}
+
+// Watch shows a full-screen view of table, replacing it with the result of
+// fetch every interval until the user quits with q or Ctrl-C, or ctx is done.
+// The terminal is restored before Watch returns.
+func Watch(ctx context.Context, table *output.Table, fetch Fetch, opts Options) error {
+	fd := int(os.Stdin.Fd())
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("failed to set up terminal: %w", err)
+	}
+
+	fmt.Print(enterAltScreen + hideCursor)
+	defer func() {
+		fmt.Print(styleReset + showCursor + exitAltScreen)
+		_ = term.Restore(fd, oldState)
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGWINCH)
+	defer signal.Stop(signals)
+
+	keys := make(chan key)
+	go readKeys(ctx, os.Stdin, keys)
+
+	results := make(chan fetchResult, 1)
+	go func() {
+		for {
+			select {
+			case <-time.After(opts.Interval):
+			case <-ctx.Done():
+				return
+			}
+
+			table, err := fetch(ctx)
+			select {
+			case results <- fetchResult{table: table, err: err, at: time.Now()}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	v := newView(opts)
+	v.update(fetchResult{table: table, at: time.Now()})
+	v.render()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case sig := <-signals:
+			if sig != syscall.SIGWINCH {
+				return nil
+			}
+		case result := <-results:
+			v.update(result)
+		case k := <-keys:
+			if !v.handleKey(k) {
+				return nil
+			}
+		}
+		v.render()
+	}
+}
+
+// view holds the state of the watch screen between refreshes.
+type view struct {
+	opts Options
+
+	columns  []output.ColumnDefaults
+	table    *output.Table
+	previous map[string][]string
+	changed  map[string]bool
+	updated  time.Time
+	err      error
+
+	sortColumn int
+	descending bool
+
+	filter    string
+	filtering bool
+	offset    int
+}
+
+func newView(opts Options) *view {
+	v := &view{opts: opts, sortColumn: -1}
+
+	// Start from the first --sort-by column, the rest is only applied by the table
+	if first, _, _ := strings.Cut(opts.SortBy, ","); first != "" {
+		header, order, _ := strings.Cut(first, ":")
+		v.opts.SortBy = strings.TrimSpace(header)
+		v.descending = strings.EqualFold(strings.TrimSpace(order), "desc")
+	}
+
+	return v
+}
+
+func (v *view) update(result fetchResult) {
+	v.err = result.err
+	if result.err != nil {
+		return
+	}
+
+	v.table = result.table
+	v.columns = result.table.Columns()
+	v.updated = result.at
+
+	if v.sortColumn == -1 {
+		for i, column := range v.columns {
+			if strings.EqualFold(column.Header, v.opts.SortBy) {
+				v.sortColumn = i
+			}
+		}
+	}
+	v.sort()
+
+	current := rowsByKey(v.table)
+	v.changed = make(map[string]bool)
+	if v.previous != nil {
+		for k, cells := range current {
+			if old, ok := v.previous[k]; !ok || !equalCells(old, cells) {
+				v.changed[k] = true
+			}
+		}
+	}
+	v.previous = current
+}
+
+func (v *view) sort() {
+	if v.table == nil || v.sortColumn < 0 || v.sortColumn >= len(v.columns) {
+		return
+	}
+
+	order := "asc"
+	if v.descending {
+		order = "desc"
+	}
+	// The column comes from the table itself, so sorting cannot fail
+	_ = v.table.Sort(output.ParseSortColumns(v.columns[v.sortColumn].Header + ":" + order))
+}
+
+// rowsByKey indexes rows by name, numbering duplicates such as the replicas
+// of a shard so every row can be matched with its previous version.
+func rowsByKey(table *output.Table) map[string][]string {
+	rows := table.Rows()
+	keys := rowKeys(table)
+	byKey := make(map[string][]string, len(rows))
+	for i, cells := range rows {
+		byKey[keys[i]] = cells
+	}
+	return byKey
+}
+
+func rowKeys(table *output.Table) []string {
+	names := table.Names()
+	seen := make(map[string]int, len(names))
+	keys := make([]string, len(names))
+	for i, name := range names {
+		keys[i] = fmt.Sprintf("%s#%d", name, seen[name])
+		seen[name]++
+	}
+	return keys
+}
+
+func equalCells(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// handleKey applies a key press and returns false when the view should close.
+func (v *view) handleKey(k key) bool {
+	if k == keyCtrlC {
+		return false
+	}
+
+	if v.filtering {
+		switch {
+		case k == keyEnter:
+			v.filtering = false
+		case k == keyEscape:
+			v.filtering = false
+			v.filter = ""
+		case k == keyBackspace:
+			if v.filter != "" {
+				_, size := utf8.DecodeLastRuneInString(v.filter)
+				v.filter = v.filter[:len(v.filter)-size]
+			}
+		case k.isPrintable():
+			v.filter += string(k)
+		}
+		v.offset = 0
+		return true
+	}
+
+	switch k {
+	case 'q', 'Q':
+		return false
+	case '/':
+		v.filtering = true
+	case keyEscape:
+		v.filter = ""
+	case keyDown, 'j':
+		v.offset++
+	case keyUp, 'k':
+		v.offset--
+	case keyPageDown, ' ':
+		v.offset += v.pageSize()
+	case keyPageUp:
+		v.offset -= v.pageSize()
+	case keyHome, 'g':
+		v.offset = 0
+	case keyEnd, 'G':
+		v.offset = len(v.visibleRows())
+	case keyRight, '>':
+		v.moveSort(1)
+	case keyLeft, '<':
+		v.moveSort(-1)
+	case 'r':
+		v.descending = !v.descending
+		v.sort()
+	}
+	return true
+}
+
+func (v *view) moveSort(delta int) {
+	if len(v.columns) == 0 {
+		return
+	}
+	if v.sortColumn < 0 {
+		v.sortColumn = 0
+	} else {
+		v.sortColumn = (v.sortColumn + delta + len(v.columns)) % len(v.columns)
+	}
+	v.sort()
+}
+
+type visibleRow struct {
+	cells   []string
+	changed bool
+}
+
+// visibleRows returns the rows matching the filter, case-insensitively on any cell.
+func (v *view) visibleRows() []visibleRow {
+	if v.table == nil {
+		return nil
+	}
+
+	filter := strings.ToLower(v.filter)
+	keys := rowKeys(v.table)
+	var rows []visibleRow
+	for i, cells := range v.table.Rows() {
+		if filter != "" && !strings.Contains(strings.ToLower(strings.Join(cells, "\t")), filter) {
+			continue
+		}
+		rows = append(rows, visibleRow{cells: cells, changed: v.changed[keys[i]]})
+	}
+	return rows
+}
+
+func screenSize() (int, int) {
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+// pageSize is the number of rows between the header line and the footer.
+func (v *view) pageSize() int {
+	_, height := screenSize()
+	if height < 5 {
+		return 1
+	}
+	return height - 4
+}
+
+func (v *view) render() {
+	width, _ := screenSize()
+	rows := v.visibleRows()
+	page := v.pageSize()
+
+	if v.offset > len(rows)-page {
+		v.offset = len(rows) - page
+	}
+	if v.offset < 0 {
+		v.offset = 0
+	}
+
+	var b strings.Builder
+	b.WriteString(moveHome)
+
+	status := fmt.Sprintf("Every %s: %s", v.opts.Interval, v.opts.Title)
+	if !v.updated.IsZero() {
+		status += "    " + v.updated.Format("15:04:05")
+	}
+	writeLine(&b, status, width, "")
+
+	widths, hidden := columnWidths(v.columns, rows, v.sortColumn)
+	var header []string
+	for i, column := range v.columns {
+		if hidden[i] {
+			continue
+		}
+		title := column.Header
+		if i == v.sortColumn {
+			if v.descending {
+				title += "↓"
+			} else {
+				title += "↑"
+			}
+		}
+		header = append(header, pad(title, widths[i]))
+	}
+	writeLine(&b, strings.Join(header, "  "), width, styleHeader)
+
+	end := v.offset + page
+	if end > len(rows) {
+		end = len(rows)
+	}
+	for _, row := range rows[v.offset:end] {
+		var cells []string
+		for i, cell := range row.cells {
+			if !hidden[i] {
+				cells = append(cells, pad(cell, widths[i]))
+			}
+		}
+		style := ""
+		if row.changed {
+			style = styleChanged
+		}
+		writeLine(&b, strings.Join(cells, "  "), width, style)
+	}
+	for i := end - v.offset; i < page; i++ {
+		writeLine(&b, "", width, "")
+	}
+
+	switch {
+	case v.err != nil:
+		writeLine(&b, "Error: "+v.err.Error(), width, styleError)
+	case v.filtering || v.filter != "":
+		writeLine(&b, fmt.Sprintf("Filter: %s", v.filter), width, "")
+	default:
+		writeLine(&b, "", width, "")
+	}
+
+	help := "q quit  ↑↓ scroll  ←→ sort column  r reverse  / filter"
+	if v.filtering {
+		help = "Enter apply filter  Esc clear filter"
+	}
+	if v.table != nil {
+		help = fmt.Sprintf("%d/%d rows  %s", len(rows), len(v.table.Rows()), help)
+	}
+	b.WriteString(styleDim + truncate(help, width) + styleReset + clearLine + clearBelow)
+
+	os.Stdout.WriteString(b.String())
+}
+
+// columnWidths sizes every column to its widest cell and hides columns that
+// are empty in every row, like the plain table output. The sort column has
+// room for the sort direction arrow.
+func columnWidths(columns []output.ColumnDefaults, rows []visibleRow, sortColumn int) ([]int, []bool) {
+	widths := make([]int, len(columns))
+	hidden := make([]bool, len(columns))
+	for i, column := range columns {
+		widths[i] = utf8.RuneCountInString(column.Header)
+		if i == sortColumn {
+			widths[i]++
+		}
+		hidden[i] = len(rows) > 0
+		for _, row := range rows {
+			if row.cells[i] != "" {
+				hidden[i] = false
+			}
+			if w := utf8.RuneCountInString(row.cells[i]); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	return widths, hidden
+}
+
+func writeLine(b *strings.Builder, line string, width int, style string) {
+	if style != "" {
+		b.WriteString(style)
+	}
+	b.WriteString(truncate(line, width))
+	if style != "" {
+		b.WriteString(styleReset)
+	}
+	// Raw mode does not translate newlines, so return the carriage explicitly
+	b.WriteString(clearLine + "\r\n")
+}
+
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	return string([]rune(s)[:width])
+}
+
+func pad(s string, width int) string {
+	if n := utf8.RuneCountInString(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
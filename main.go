@@ -2,29 +2,25 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/pincher95/esctl/cmd"
 )
 
 func main() {
-	// Create a cancellable context that listens for SIGINT or SIGTERM
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	// Cancel the context on SIGINT or SIGTERM, aborting in-flight requests and watch loops
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 
-	// sigChan := make(chan os.Signal, 1)
-	// signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	// Restore the default behaviour once cancelled, so a second Ctrl-C exits immediately
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
 
-	// go func() {
-	// 	<-sigChan
-	// 	fmt.Println("Received an interrupt, cancelling context...")
-	// 	cancel()
-	// }()
-
-	// Execute our root command with the context
-	if err := cmd.Execute(ctx); err != nil {
-		fmt.Println("Error:", err)
-		os.Exit(1)
-	}
+	// Execute our root command with the context, it prints any error itself
+	code := cmd.Execute(ctx)
+	stop()
+	os.Exit(code)
 }
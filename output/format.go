@@ -0,0 +1,125 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+)
+
+const (
+	FormatTable          = "table"
+	FormatWide           = "wide"
+	FormatJSON           = "json"
+	FormatYAML           = "yaml"
+	FormatCSV            = "csv"
+	FormatTSV            = "tsv"
+	FormatName           = "name"
+	FormatGoTemplate     = "go-template"
+	FormatGoTemplateFile = "go-template-file"
+	FormatJSONPath       = "jsonpath"
+	FormatJSONPathFile   = "jsonpath-file"
+)
+
+// Formats lists the values accepted by --output.
+var Formats = []string{
+	FormatTable, FormatWide, FormatJSON, FormatYAML, FormatCSV, FormatTSV, FormatName,
+	FormatGoTemplate + "=TEMPLATE", FormatGoTemplateFile + "=FILE",
+	FormatJSONPath + "=EXPRESSION", FormatJSONPathFile + "=FILE",
+}
+
+var (
+	format       = FormatTable
+	goTemplate   *template.Template
+	jsonPathExpr *jsonPath
+)
+
+// SetFormat selects how tables and objects are printed. Template formats
+// take their template after '=', e.g. 'jsonpath={.status}'.
+func SetFormat(f string) error {
+	name, argument, hasArgument := strings.Cut(strings.TrimSpace(f), "=")
+	name = strings.ToLower(name)
+	if name == "" {
+		name = FormatTable
+	}
+
+	switch name {
+	case FormatTable, FormatWide, FormatJSON, FormatYAML, FormatCSV, FormatTSV, FormatName:
+		if hasArgument {
+			return fmt.Errorf("output format %q does not take an argument", name)
+		}
+	case FormatGoTemplate, FormatGoTemplateFile, FormatJSONPath, FormatJSONPathFile:
+		if argument == "" {
+			return fmt.Errorf("output format %q requires a template, e.g. %s=...", name, name)
+		}
+		if err := parseTemplate(name, argument); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown output format %q, expected one of: %s", f, strings.Join(Formats, ", "))
+	}
+
+	format = name
+	return nil
+}
+
+func parseTemplate(name, argument string) error {
+	text := argument
+	if name == FormatGoTemplateFile || name == FormatJSONPathFile {
+		data, err := os.ReadFile(argument)
+		if err != nil {
+			return fmt.Errorf("failed to read template file: %w", err)
+		}
+		text = string(data)
+	}
+
+	var err error
+	switch name {
+	case FormatGoTemplate, FormatGoTemplateFile:
+		goTemplate, err = template.New("output").Parse(text)
+	case FormatJSONPath, FormatJSONPathFile:
+		jsonPathExpr, err = parseJSONPath(text)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return nil
+}
+
+// Format returns the selected output format.
+func Format() string {
+	return format
+}
+
+// IsWide reports whether every available column should be shown.
+func IsWide() bool {
+	return format == FormatWide
+}
+
+// printTemplate renders data with the go-template or jsonpath given to
+// --output. Go templates see the typed values, e.g. '{{range .}}{{.Index}}{{end}}',
+// while JSONPath works on their JSON form, e.g. '{[*].index}'.
+func printTemplate(data interface{}) error {
+	var err error
+	switch format {
+	case FormatGoTemplate, FormatGoTemplateFile:
+		err = goTemplate.Execute(os.Stdout, data)
+	case FormatJSONPath, FormatJSONPathFile:
+		var generic interface{}
+		if generic, err = toGeneric(data); err == nil {
+			err = jsonPathExpr.execute(os.Stdout, generic)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to execute output template: %w", err)
+	}
+	return nil
+}
+
+func isTemplateFormat() bool {
+	switch format {
+	case FormatGoTemplate, FormatGoTemplateFile, FormatJSONPath, FormatJSONPathFile:
+		return true
+	}
+	return false
+}
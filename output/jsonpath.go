@@ -0,0 +1,380 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonPath is a template in the kubectl JSONPath syntax, e.g.
+// '{range [*]}{.index}{"\t"}{.health}{"\n"}{end}'. It supports field access
+// with '.name' or "['name']", array indexes and '[*]', '.*', filters like
+// '[?(@.health=="red")]', string literals and range/end blocks.
+type jsonPath struct {
+	nodes []jsonPathNode
+}
+
+type jsonPathNode struct {
+	text    string
+	literal bool
+	path    []pathSegment
+	body    []jsonPathNode // set for range blocks
+	isRange bool
+}
+
+type segmentKind int
+
+const (
+	segmentField segmentKind = iota
+	segmentIndex
+	segmentWildcard
+	segmentFilter
+)
+
+type pathSegment struct {
+	kind   segmentKind
+	field  string
+	index  int
+	filter *pathFilter
+}
+
+type pathFilter struct {
+	path     []pathSegment
+	operator string
+	value    string
+}
+
+func parseJSONPath(template string) (*jsonPath, error) {
+	root := []jsonPathNode{}
+	stack := [][]jsonPathNode{}
+	current := &root
+
+	rest := template
+	for rest != "" {
+		start := strings.Index(rest, "{")
+		if start == -1 {
+			*current = append(*current, jsonPathNode{text: rest, literal: true})
+			break
+		}
+		if start > 0 {
+			*current = append(*current, jsonPathNode{text: rest[:start], literal: true})
+		}
+
+		end := closingBrace(rest, start)
+		if end == -1 {
+			return nil, fmt.Errorf("unclosed '{' in jsonpath %q", template)
+		}
+		expr := strings.TrimSpace(rest[start+1 : end])
+		rest = rest[end+1:]
+
+		switch {
+		case expr == "end":
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("'end' without 'range' in jsonpath %q", template)
+			}
+			body := *current
+			parent := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			parent[len(parent)-1].body = body
+			*current = parent
+		case strings.HasPrefix(expr, "range "):
+			path, err := parsePath(strings.TrimSpace(strings.TrimPrefix(expr, "range ")))
+			if err != nil {
+				return nil, err
+			}
+			*current = append(*current, jsonPathNode{path: path, isRange: true})
+			stack = append(stack, *current)
+			*current = []jsonPathNode{}
+		case strings.HasPrefix(expr, `"`) || strings.HasPrefix(expr, "'"):
+			text, err := unquote(expr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid string %s in jsonpath: %w", expr, err)
+			}
+			*current = append(*current, jsonPathNode{text: text, literal: true})
+		default:
+			path, err := parsePath(expr)
+			if err != nil {
+				return nil, err
+			}
+			*current = append(*current, jsonPathNode{path: path})
+		}
+	}
+
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("'range' without 'end' in jsonpath %q", template)
+	}
+
+	return &jsonPath{nodes: root}, nil
+}
+
+// closingBrace returns the position of the '}' closing the '{' at start,
+// ignoring braces inside quoted strings.
+func closingBrace(s string, start int) int {
+	var quote byte
+	for i := start + 1; i < len(s); i++ {
+		switch {
+		case quote != 0 && s[i] == '\\':
+			i++
+		case quote != 0 && s[i] == quote:
+			quote = 0
+		case quote == 0 && (s[i] == '"' || s[i] == '\''):
+			quote = s[i]
+		case quote == 0 && s[i] == '}':
+			return i
+		}
+	}
+	return -1
+}
+
+func unquote(s string) (string, error) {
+	if strings.HasPrefix(s, "'") {
+		if len(s) < 2 || !strings.HasSuffix(s, "'") {
+			return "", fmt.Errorf("unterminated string")
+		}
+		return s[1 : len(s)-1], nil
+	}
+	return strconv.Unquote(s)
+}
+
+func parsePath(expr string) ([]pathSegment, error) {
+	p := strings.TrimPrefix(strings.TrimPrefix(expr, "$"), "@")
+	var segments []pathSegment
+
+	for p != "" {
+		switch {
+		case strings.HasPrefix(p, ".*"):
+			segments = append(segments, pathSegment{kind: segmentWildcard})
+			p = p[2:]
+		case strings.HasPrefix(p, "."):
+			p = p[1:]
+			end := strings.IndexAny(p, ".[")
+			if end == -1 {
+				end = len(p)
+			}
+			if end > 0 {
+				segments = append(segments, pathSegment{kind: segmentField, field: p[:end]})
+			}
+			p = p[end:]
+		case strings.HasPrefix(p, "["):
+			end := closingBracket(p)
+			if end == -1 {
+				return nil, fmt.Errorf("unclosed '[' in jsonpath expression %q", expr)
+			}
+			segment, err := parseBracket(strings.TrimSpace(p[1:end]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid jsonpath expression %q: %w", expr, err)
+			}
+			segments = append(segments, segment)
+			p = p[end+1:]
+		default:
+			return nil, fmt.Errorf("invalid jsonpath expression %q, expected '.' or '[' at %q", expr, p)
+		}
+	}
+
+	return segments, nil
+}
+
+func closingBracket(s string) int {
+	var quote byte
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quote != 0 && s[i] == quote:
+			quote = 0
+		case quote != 0:
+		case s[i] == '"' || s[i] == '\'':
+			quote = s[i]
+		case s[i] == '[':
+			depth++
+		case s[i] == ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func parseBracket(content string) (pathSegment, error) {
+	switch {
+	case content == "*":
+		return pathSegment{kind: segmentWildcard}, nil
+	case strings.HasPrefix(content, "'") || strings.HasPrefix(content, `"`):
+		field, err := unquote(content)
+		if err != nil {
+			return pathSegment{}, err
+		}
+		return pathSegment{kind: segmentField, field: field}, nil
+	case strings.HasPrefix(content, "?(") && strings.HasSuffix(content, ")"):
+		filter, err := parseFilter(strings.TrimSpace(content[2 : len(content)-1]))
+		if err != nil {
+			return pathSegment{}, err
+		}
+		return pathSegment{kind: segmentFilter, filter: filter}, nil
+	}
+
+	index, err := strconv.Atoi(content)
+	if err != nil {
+		return pathSegment{}, fmt.Errorf("unsupported subscript [%s]", content)
+	}
+	return pathSegment{kind: segmentIndex, index: index}, nil
+}
+
+func parseFilter(expr string) (*pathFilter, error) {
+	for _, operator := range []string{"==", "!="} {
+		left, right, found := strings.Cut(expr, operator)
+		if !found {
+			continue
+		}
+		path, err := parsePath(strings.TrimSpace(left))
+		if err != nil {
+			return nil, err
+		}
+		value := strings.TrimSpace(right)
+		if unquoted, err := unquote(value); err == nil {
+			value = unquoted
+		}
+		return &pathFilter{path: path, operator: operator, value: value}, nil
+	}
+
+	path, err := parsePath(expr)
+	if err != nil {
+		return nil, err
+	}
+	return &pathFilter{path: path}, nil
+}
+
+func (j *jsonPath) execute(w io.Writer, data interface{}) error {
+	return executeNodes(w, j.nodes, data)
+}
+
+func executeNodes(w io.Writer, nodes []jsonPathNode, data interface{}) error {
+	for _, node := range nodes {
+		if node.literal {
+			if _, err := io.WriteString(w, node.text); err != nil {
+				return err
+			}
+			continue
+		}
+
+		results := evaluatePath(node.path, data)
+		if node.isRange {
+			for _, result := range results {
+				if err := executeNodes(w, node.body, result); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		values := make([]string, len(results))
+		for i, result := range results {
+			values[i] = formatJSONValue(result)
+		}
+		if _, err := io.WriteString(w, strings.Join(values, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func evaluatePath(segments []pathSegment, data interface{}) []interface{} {
+	results := []interface{}{data}
+
+	for _, segment := range segments {
+		var next []interface{}
+		for _, value := range results {
+			next = append(next, applySegment(segment, value)...)
+		}
+		results = next
+	}
+
+	return results
+}
+
+func applySegment(segment pathSegment, value interface{}) []interface{} {
+	switch segment.kind {
+	case segmentField:
+		if m, ok := value.(map[string]interface{}); ok {
+			if field, exists := m[segment.field]; exists {
+				return []interface{}{field}
+			}
+		}
+	case segmentIndex:
+		if list, ok := value.([]interface{}); ok {
+			index := segment.index
+			if index < 0 {
+				index += len(list)
+			}
+			if index >= 0 && index < len(list) {
+				return []interface{}{list[index]}
+			}
+		}
+	case segmentWildcard:
+		switch v := value.(type) {
+		case []interface{}:
+			return v
+		case map[string]interface{}:
+			keys := sortedKeys(v)
+			values := make([]interface{}, len(keys))
+			for i, key := range keys {
+				values[i] = v[key]
+			}
+			return values
+		}
+	case segmentFilter:
+		list, ok := value.([]interface{})
+		if !ok {
+			return nil
+		}
+		var matches []interface{}
+		for _, item := range list {
+			if segment.filter.matches(item) {
+				matches = append(matches, item)
+			}
+		}
+		return matches
+	}
+	return nil
+}
+
+func (f *pathFilter) matches(item interface{}) bool {
+	results := evaluatePath(f.path, item)
+	switch f.operator {
+	case "==":
+		return len(results) > 0 && formatJSONValue(results[0]) == f.value
+	case "!=":
+		return len(results) == 0 || formatJSONValue(results[0]) != f.value
+	}
+	return len(results) > 0
+}
+
+func formatJSONValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	case int64, float64, bool:
+		return fmt.Sprint(v)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,55 @@
+package output
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJSONPath(t *testing.T) {
+	data := []interface{}{
+		map[string]interface{}{"index": "logs-1", "health": "green", "store.size": "1gb", "pri": int64(1)},
+		map[string]interface{}{"index": "logs-2", "health": "red", "store.size": "2gb", "pri": int64(3)},
+	}
+
+	testCases := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"Wildcard", "{[*].index}", "logs-1 logs-2"},
+		{"Leading dot", "{.[*].health}", "green red"},
+		{"Index", "{[1].index}", "logs-2"},
+		{"Negative index", "{[-1].pri}", "3"},
+		{"Quoted field", "{[0]['store.size']}", "1gb"},
+		{"Filter", `{[?(@.health=="red")].index}`, "logs-2"},
+		{"Filter not equal", `{[?(@.health!="red")].index}`, "logs-1"},
+		{"Range", `{range [*]}{.index}{":"}{.pri}{"\n"}{end}`, "logs-1:1\nlogs-2:3\n"},
+		{"Text around expressions", "first={[0].index}", "first=logs-1"},
+		{"Missing field", "{[0].missing}", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path, err := parseJSONPath(tc.template)
+			if err != nil {
+				t.Fatalf("parseJSONPath(%q) returned %v", tc.template, err)
+			}
+
+			var out strings.Builder
+			if err := path.execute(&out, data); err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != tc.expected {
+				t.Errorf("got %q, want %q", out.String(), tc.expected)
+			}
+		})
+	}
+}
+
+func TestJSONPathInvalid(t *testing.T) {
+	for _, template := range []string{"{.index", "{range [*]}{.index}", "{end}", "{[abc]}", "{index}"} {
+		if _, err := parseJSONPath(template); err == nil {
+			t.Errorf("expected error for %q", template)
+		}
+	}
+}
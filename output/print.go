@@ -0,0 +1,193 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Table pairs the items returned by Elasticsearch with the row each of them
+// is shown as, so the same result can be printed in any output format.
+type Table struct {
+	columns     []ColumnDefaults
+	nameColumns []string
+	items       []interface{}
+	rows        []map[string]string
+}
+
+// NewTable creates a table with the given columns. The values of
+// nameColumns, joined by '/', identify a row in the 'name' output format.
+func NewTable(columns []ColumnDefaults, nameColumns ...string) *Table {
+	return &Table{columns: columns, nameColumns: nameColumns}
+}
+
+// AddRow adds an item and its cell values keyed by column header.
+func (t *Table) AddRow(item interface{}, values map[string]string) {
+	t.items = append(t.items, item)
+	t.rows = append(t.rows, values)
+}
+
+// Columns returns the displayed columns.
+func (t *Table) Columns() []ColumnDefaults {
+	return t.columns
+}
+
+// Rows returns the cells of every row, in the order of Columns.
+func (t *Table) Rows() [][]string {
+	rows := make([][]string, len(t.rows))
+	for i, values := range t.rows {
+		rows[i] = t.cells(values)
+	}
+	return rows
+}
+
+// Names returns the identifying name of every row, as printed by '-o name'.
+func (t *Table) Names() []string {
+	nameColumns := t.nameColumns
+	if len(nameColumns) == 0 && len(t.columns) > 0 {
+		nameColumns = []string{t.columns[0].Header}
+	}
+
+	names := make([]string, len(t.rows))
+	for i, values := range t.rows {
+		parts := make([]string, len(nameColumns))
+		for k, header := range nameColumns {
+			parts[k] = values[header]
+		}
+		names[i] = strings.Join(parts, "/")
+	}
+	return names
+}
+
+// Print sorts the table and prints it in the selected output format.
+func (t *Table) Print(sortCols []sortColumn) error {
+	if err := t.Sort(sortCols); err != nil {
+		return err
+	}
+
+	if isTemplateFormat() {
+		return printTemplate(t.items)
+	}
+
+	switch format {
+	case FormatJSON, FormatYAML:
+		return PrintObject(t.items)
+	case FormatCSV:
+		return t.printDelimited(',')
+	case FormatTSV:
+		return t.printDelimited('\t')
+	case FormatName:
+		t.printNames()
+		return nil
+	default:
+		return PrintTable(t.columns, t.Rows(), nil)
+	}
+}
+
+func (t *Table) cells(values map[string]string) []string {
+	row := make([]string, len(t.columns))
+	for i, columnDef := range t.columns {
+		row[i] = values[columnDef.Header]
+	}
+	return row
+}
+
+// Sort orders the rows by the given columns. It fails if a column is unknown.
+func (t *Table) Sort(sortCols []sortColumn) error {
+	if len(sortCols) == 0 {
+		return nil
+	}
+
+	types := make(map[string]ColumnType)
+	for _, columnDef := range t.columns {
+		types[strings.ToLower(columnDef.Header)] = columnDef.Type
+	}
+
+	headers := make([]string, len(sortCols))
+	for i, sc := range sortCols {
+		if _, exists := types[strings.ToLower(sc.header)]; !exists {
+			return fmt.Errorf("header '%s' is not a valid column", sc.header)
+		}
+		for _, columnDef := range t.columns {
+			if strings.EqualFold(columnDef.Header, sc.header) {
+				headers[i] = columnDef.Header
+			}
+		}
+	}
+
+	order := make([]int, len(t.rows))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		for k, sc := range sortCols {
+			left, right := t.rows[order[i]][headers[k]], t.rows[order[j]][headers[k]]
+			if left == right {
+				continue
+			}
+
+			cmpAsc := compareValues(left, right, types[strings.ToLower(sc.header)])
+			if sc.descending {
+				return !cmpAsc
+			}
+			return cmpAsc
+		}
+		return false
+	})
+
+	items := make([]interface{}, len(order))
+	rows := make([]map[string]string, len(order))
+	for i, idx := range order {
+		items[i] = t.items[idx]
+		rows[i] = t.rows[idx]
+	}
+	t.items, t.rows = items, rows
+	return nil
+}
+
+func (t *Table) printDelimited(delimiter rune) error {
+	w := csv.NewWriter(os.Stdout)
+	w.Comma = delimiter
+
+	headers := make([]string, len(t.columns))
+	for i, columnDef := range t.columns {
+		headers[i] = columnDef.Header
+	}
+	_ = w.Write(headers)
+
+	for _, values := range t.rows {
+		_ = w.Write(t.cells(values))
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
+
+func (t *Table) printNames() {
+	for _, name := range t.Names() {
+		fmt.Println(name)
+	}
+}
+
+// PrintObject prints a single response, such as a cluster health report or a
+// search result. It is printed as JSON unless YAML output was selected.
+func PrintObject(data interface{}) error {
+	if isTemplateFormat() {
+		return printTemplate(data)
+	}
+
+	switch format {
+	case FormatTable, FormatWide, FormatJSON:
+		return PrintJson(data)
+	case FormatYAML:
+		return PrintYaml(data)
+	default:
+		return fmt.Errorf("output format '%s' is not supported by this command, use json, yaml, go-template or jsonpath", format)
+	}
+}
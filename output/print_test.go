@@ -0,0 +1,54 @@
+package output
+
+import "testing"
+
+func TestTableSortKeepsItemsWithRows(t *testing.T) {
+	table := NewTable([]ColumnDefaults{
+		{Header: "INDEX", Type: Text},
+		{Header: "STORE-SIZE", Type: DataSize},
+	}, "INDEX")
+
+	table.AddRow("small", map[string]string{"INDEX": "small", "STORE-SIZE": "1kb"})
+	table.AddRow("large", map[string]string{"INDEX": "large", "STORE-SIZE": "10gb"})
+	table.AddRow("medium", map[string]string{"INDEX": "medium", "STORE-SIZE": "3mb"})
+
+	if err := table.Sort(ParseSortColumns("store-size:desc")); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"large", "medium", "small"}
+	for i, name := range expected {
+		if table.items[i] != name || table.rows[i]["INDEX"] != name {
+			t.Errorf("row %d: got item %v and row %v, want %s", i, table.items[i], table.rows[i]["INDEX"], name)
+		}
+	}
+}
+
+func TestTableSortRejectsUnknownColumn(t *testing.T) {
+	table := NewTable([]ColumnDefaults{{Header: "INDEX", Type: Text}}, "INDEX")
+	table.AddRow("a", map[string]string{"INDEX": "a"})
+
+	if err := table.Sort(ParseSortColumns("size")); err == nil {
+		t.Error("expected an error for an unknown sort column")
+	}
+}
+
+func TestSetFormat(t *testing.T) {
+	defer SetFormat(FormatTable)
+
+	for _, f := range []string{FormatTable, FormatWide, FormatJSON, FormatYAML, FormatCSV, FormatTSV, FormatName, "jsonpath={.status}", "go-template={{.Status}}"} {
+		if err := SetFormat(f); err != nil {
+			t.Errorf("SetFormat(%q) returned %v", f, err)
+		}
+	}
+
+	if err := SetFormat("JSON"); err != nil || Format() != FormatJSON {
+		t.Errorf("expected format names to be case-insensitive")
+	}
+
+	for _, f := range []string{"xml", "json=x", "jsonpath=", "go-template={{.Status"} {
+		if err := SetFormat(f); err == nil {
+			t.Errorf("expected error for %q", f)
+		}
+	}
+}
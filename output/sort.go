@@ -81,19 +81,21 @@ func parseDataSize(sizeStr string) (float64, error) {
 		return 0, nil
 	}
 
-	sizeStr = strings.ToLower(sizeStr)
-	var value float64
-	var unit string
+	// Rates such as '12.5mb/s' compare like the size per second
+	sizeStr = strings.TrimSuffix(strings.ToLower(sizeStr), "/s")
+	// Values without a unit are bytes, as returned with '--bytes b'
+	value, unit := 0.0, "b"
 	var err error
 
-	for i := 0; i < len(sizeStr); i++ {
-		if (sizeStr[i] < '0' || sizeStr[i] > '9') && sizeStr[i] != '.' {
+	for i := 0; i <= len(sizeStr); i++ {
+		if i == len(sizeStr) || ((sizeStr[i] < '0' || sizeStr[i] > '9') && sizeStr[i] != '.') {
 			value, err = strconv.ParseFloat(sizeStr[:i], 64)
 			if err != nil {
-				fmt.Println(err)
 				return 0, err
 			}
-			unit = sizeStr[i:]
+			if i < len(sizeStr) {
+				unit = sizeStr[i:]
+			}
 			break
 		}
 	}
@@ -109,8 +111,9 @@ func parseDataSize(sizeStr string) (float64, error) {
 		return value * 1024 * 1024 * 1024, nil
 	case "tb":
 		return value * 1024 * 1024 * 1024 * 1024, nil
+	case "pb":
+		return value * 1024 * 1024 * 1024 * 1024 * 1024, nil
 	default:
-		fmt.Printf("unknown unit: %s\n", unit)
 		return 0, fmt.Errorf("unknown unit: %s", unit)
 	}
 }
@@ -20,6 +20,7 @@ func TestParseDataSize(t *testing.T) {
 		{"Invalid unit", "10ab", 0},
 		{"Invalid value", "ab10", 0},
 		{"Mixed case", "10Kb", 10 * 1024},
+		{"Rate per second", "10mb/s", 10 * 1024 * 1024},
 	}
 
 	for _, tc := range testCases {
@@ -11,6 +11,8 @@ import (
 type ColumnDefaults struct {
 	Header string
 	Type   ColumnType
+	// Wide columns are only shown with '-o wide' or when selected with --columns
+	Wide bool
 }
 
 type sortColumn struct {
@@ -45,7 +47,7 @@ func compareValues(left, right string, columnType ColumnType) bool {
 	return false
 }
 
-func PrintTable(columnDefs []ColumnDefaults, data [][]string, sortCols []sortColumn) {
+func PrintTable(columnDefs []ColumnDefaults, data [][]string, sortCols []sortColumn) error {
 	// Detect empty columns (unchanged from your snippet):
 	emptyColumns := make([]bool, len(columnDefs))
 	for i := range columnDefs {
@@ -71,8 +73,7 @@ func PrintTable(columnDefs []ColumnDefaults, data [][]string, sortCols []sortCol
 		// Validate each column
 		for _, sc := range sortCols {
 			if _, exists := headerIndexMap[strings.ToLower(sc.header)]; !exists {
-				fmt.Fprintf(os.Stderr, "header '%s' is not a valid column\n", sc.header)
-				os.Exit(1)
+				return fmt.Errorf("header '%s' is not a valid column", sc.header)
 			}
 		}
 
@@ -102,7 +103,6 @@ func PrintTable(columnDefs []ColumnDefaults, data [][]string, sortCols []sortCol
 
 	// Prepare writer
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	defer w.Flush()
 
 	// Write headers
 	for i, columnDef := range columnDefs {
@@ -121,6 +121,8 @@ func PrintTable(columnDefs []ColumnDefaults, data [][]string, sortCols []sortCol
 		}
 		fmt.Fprintln(w)
 	}
+
+	return w.Flush()
 }
 
 func ParseSortColumns(sortByStr string) []sortColumn {
@@ -0,0 +1,258 @@
+package output
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Condition is a single clause of a --where filter, e.g. 'STORE-SIZE>10gb'.
+type Condition struct {
+	header   string
+	operator string
+	value    string
+	regexp   *regexp.Regexp
+}
+
+var conditionRegexp = regexp.MustCompile(`^\s*([A-Za-z0-9_.-]+)\s*(=~|!~|>=|<=|!=|==|=|>|<)\s*(.*?)\s*$`)
+
+// conditionStart matches the start of a condition, used to tell the commas
+// separating conditions from commas within a value.
+var conditionStart = regexp.MustCompile(`^\s*[A-Za-z0-9_.-]+\s*(=~|!~|>=|<=|!=|==|=|>|<)`)
+
+// ParseWhere parses comma-separated conditions such as
+// 'STORE-SIZE>10gb,HEALTH!=green,INDEX=~^logs-'. Supported operators are
+// =, ==, !=, <, <=, >, >= and =~, !~ for regular expressions. A comma only
+// separates conditions when a new condition follows it, so values such as
+// '^logs-\d{1,3}' may contain commas.
+func ParseWhere(where string) ([]Condition, error) {
+	var conditions []Condition
+
+	for _, clause := range splitConditions(where) {
+		if strings.TrimSpace(clause) == "" {
+			continue
+		}
+
+		match := conditionRegexp.FindStringSubmatch(clause)
+		if match == nil {
+			return nil, fmt.Errorf("invalid condition %q, expected COLUMN<operator>VALUE", clause)
+		}
+
+		condition := Condition{header: match[1], operator: match[2], value: match[3]}
+		if condition.operator == "==" {
+			condition.operator = "="
+		}
+		if condition.operator == "=~" || condition.operator == "!~" {
+			re, err := regexp.Compile(condition.value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid regular expression in %q: %w", clause, err)
+			}
+			condition.regexp = re
+		}
+
+		conditions = append(conditions, condition)
+	}
+
+	return conditions, nil
+}
+
+// Equality returns the value of the first '=' condition on header, e.g.
+// 'green' for 'HEALTH=green', so commands can let the server filter rows too.
+func Equality(conditions []Condition, header string) (string, bool) {
+	for _, condition := range conditions {
+		if condition.operator == "=" && strings.EqualFold(condition.header, header) {
+			return condition.value, true
+		}
+	}
+
+	return "", false
+}
+
+// splitConditions splits where at the commas followed by a condition,
+// keeping any other comma in the value of the preceding condition.
+func splitConditions(where string) []string {
+	var clauses []string
+	for _, part := range strings.Split(where, ",") {
+		if len(clauses) > 0 && !conditionStart.MatchString(part) && strings.TrimSpace(part) != "" {
+			clauses[len(clauses)-1] += "," + part
+			continue
+		}
+		clauses = append(clauses, part)
+	}
+	return clauses
+}
+
+// Filter drops the rows that do not match every condition. Columns gives the
+// type of each column a condition may refer to, including hidden ones.
+func (t *Table) Filter(conditions []Condition, columns []ColumnDefaults) error {
+	if len(conditions) == 0 {
+		return nil
+	}
+
+	headers := make([]string, len(conditions))
+	types := make([]ColumnType, len(conditions))
+	for i, condition := range conditions {
+		found := false
+		for _, columnDef := range columns {
+			if strings.EqualFold(columnDef.Header, condition.header) {
+				headers[i], types[i], found = columnDef.Header, columnDef.Type, true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("header '%s' is not a valid column", condition.header)
+		}
+		if err := validateConditionValue(condition, types[i]); err != nil {
+			return err
+		}
+	}
+
+	items := t.items[:0]
+	rows := t.rows[:0]
+	for i, values := range t.rows {
+		matches := true
+		for k, condition := range conditions {
+			if !condition.matches(values[headers[k]], types[k]) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			items = append(items, t.items[i])
+			rows = append(rows, values)
+		}
+	}
+	t.items, t.rows = items, rows
+
+	return nil
+}
+
+func validateConditionValue(condition Condition, columnType ColumnType) error {
+	if condition.regexp != nil {
+		return nil
+	}
+
+	var err error
+	switch columnType {
+	case Number:
+		_, err = strconv.ParseFloat(condition.value, 64)
+	case Percent:
+		_, err = parsePercent(condition.value)
+	case DataSize:
+		_, err = parseDataSize(condition.value)
+	case Date:
+		_, err = parseDate(condition.value)
+	case Boolean:
+		_, err = strconv.ParseBool(condition.value)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid value %q for column %s", condition.value, condition.header)
+	}
+	return nil
+}
+
+func (c Condition) matches(cell string, columnType ColumnType) bool {
+	switch c.operator {
+	case "=~":
+		return c.regexp.MatchString(cell)
+	case "!~":
+		return !c.regexp.MatchString(cell)
+	}
+
+	cmp, ok := compareTyped(cell, c.value, columnType)
+	if !ok {
+		// Cells that cannot be compared, e.g. sizes of unassigned shards,
+		// only match inequality
+		return c.operator == "!="
+	}
+
+	switch c.operator {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
+
+// compareTyped compares a cell with a condition value according to the
+// column type, returning false if the cell cannot be parsed.
+func compareTyped(cell, value string, columnType ColumnType) (int, bool) {
+	switch columnType {
+	case Number:
+		return compareParsed(cell, value, func(s string) (float64, error) { return strconv.ParseFloat(s, 64) })
+	case Percent:
+		return compareParsed(cell, value, parsePercent)
+	case DataSize:
+		if cell == "" {
+			return 0, false
+		}
+		return compareParsed(cell, value, parseDataSize)
+	case Date:
+		return compareParsed(cell, value, func(s string) (float64, error) {
+			t, err := parseDate(s)
+			return float64(t.UnixNano()), err
+		})
+	case Boolean:
+		return compareParsed(cell, value, func(s string) (float64, error) {
+			b, err := strconv.ParseBool(s)
+			if b {
+				return 1, err
+			}
+			return 0, err
+		})
+	}
+
+	switch {
+	case cell == value:
+		return 0, true
+	case sortText(cell, value):
+		return -1, true
+	default:
+		return 1, true
+	}
+}
+
+func compareParsed(cell, value string, parse func(string) (float64, error)) (int, bool) {
+	left, err := parse(cell)
+	if err != nil {
+		return 0, false
+	}
+	right, err := parse(value)
+	if err != nil {
+		return 0, false
+	}
+
+	switch {
+	case left < right:
+		return -1, true
+	case left > right:
+		return 1, true
+	}
+	return 0, true
+}
+
+func parsePercent(s string) (float64, error) {
+	return strconv.ParseFloat(strings.TrimSpace(strings.TrimRight(s, "%")), 64)
+}
+
+var dateLayouts = []string{"2006-01-02T15:04:05.999Z", time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"}
+
+func parseDate(s string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
@@ -0,0 +1,101 @@
+package output
+
+import "testing"
+
+func TestTableFilter(t *testing.T) {
+	columns := []ColumnDefaults{
+		{Header: "INDEX", Type: Text},
+		{Header: "HEALTH", Type: Text},
+		{Header: "STORE-SIZE", Type: DataSize},
+		{Header: "HEAP-PERCENT", Type: Percent},
+		{Header: "DOCS", Type: Number},
+	}
+
+	rows := []map[string]string{
+		{"INDEX": "logs-1", "HEALTH": "green", "STORE-SIZE": "20gb", "HEAP-PERCENT": "80%", "DOCS": "100"},
+		{"INDEX": "logs-2", "HEALTH": "yellow", "STORE-SIZE": "900mb", "HEAP-PERCENT": "9%", "DOCS": "5"},
+		{"INDEX": "metrics", "HEALTH": "red", "STORE-SIZE": "", "HEAP-PERCENT": "50%", "DOCS": "20"},
+	}
+
+	testCases := []struct {
+		where    string
+		expected []string
+	}{
+		{"STORE-SIZE>10gb", []string{"logs-1"}},
+		{"store-size<1gb", []string{"logs-2"}},
+		{"HEALTH!=green", []string{"logs-2", "metrics"}},
+		{"INDEX=~^logs-", []string{"logs-1", "logs-2"}},
+		{"INDEX!~^logs-", []string{"metrics"}},
+		{"HEAP-PERCENT>=50%", []string{"logs-1", "metrics"}},
+		{"HEAP-PERCENT<10", []string{"logs-2"}},
+		{"DOCS==20", []string{"metrics"}},
+		{"INDEX=~^logs-, HEALTH=green", []string{"logs-1"}},
+		{`INDEX=~^logs-\d{1,3}$`, []string{"logs-1", "logs-2"}},
+		{`INDEX=~^logs-\d{1,3}$,HEALTH=green`, []string{"logs-1"}},
+		{"INDEX=~^(metrics|logs-2)$,", []string{"logs-2", "metrics"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.where, func(t *testing.T) {
+			conditions, err := ParseWhere(tc.where)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			table := NewTable(columns)
+			for _, row := range rows {
+				table.AddRow(row["INDEX"], row)
+			}
+			if err := table.Filter(conditions, columns); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(table.items) != len(tc.expected) {
+				t.Fatalf("got %v, want %v", table.items, tc.expected)
+			}
+			for i, name := range tc.expected {
+				if table.items[i] != name {
+					t.Errorf("got %v, want %v", table.items, tc.expected)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestTableFilterInvalid(t *testing.T) {
+	columns := []ColumnDefaults{{Header: "STORE-SIZE", Type: DataSize}}
+
+	for _, where := range []string{"UNKNOWN=1", "STORE-SIZE>10xb"} {
+		conditions, err := ParseWhere(where)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := NewTable(columns).Filter(conditions, columns); err == nil {
+			t.Errorf("expected error for %q", where)
+		}
+	}
+
+	for _, where := range []string{"STORE-SIZE", "INDEX=~[", ">10gb"} {
+		if _, err := ParseWhere(where); err == nil {
+			t.Errorf("expected parse error for %q", where)
+		}
+	}
+}
+
+func TestEquality(t *testing.T) {
+	conditions, err := ParseWhere("INDEX=~^logs-,health==green,DOCS>5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value, ok := Equality(conditions, "HEALTH"); !ok || value != "green" {
+		t.Errorf("got %q, %v, want green", value, ok)
+	}
+	if _, ok := Equality(conditions, "INDEX"); ok {
+		t.Error("expected no equality for a regular expression")
+	}
+	if _, ok := Equality(conditions, "DOCS"); ok {
+		t.Error("expected no equality for a comparison")
+	}
+}
@@ -1,18 +1,72 @@
 package output
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
-	"os"
 
 	"gopkg.in/yaml.v2"
 )
 
-func PrintYaml(data interface{}) {
-	yamlData, err := yaml.Marshal(data)
+func PrintYaml(data interface{}) error {
+	generic, err := toGeneric(data)
 	if err != nil {
-		fmt.Println("Failed to marshal data to YAML:", err)
-		os.Exit(1)
+		return fmt.Errorf("failed to marshal data to YAML: %w", err)
 	}
 
-	fmt.Println(string(yamlData))
+	yamlData, err := yaml.Marshal(generic)
+	if err != nil {
+		return fmt.Errorf("failed to marshal data to YAML: %w", err)
+	}
+
+	fmt.Print(string(yamlData))
+	return nil
+}
+
+// toGeneric converts data to maps, slices and scalars through its JSON
+// encoding, so YAML keys follow the same json tags as the JSON output.
+func toGeneric(data interface{}) (interface{}, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.UseNumber()
+
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+
+	return normalize(generic), nil
+}
+
+// normalize drops null fields, which the cat APIs return for every column
+// that was not requested, and turns json.Number values into integers where
+// possible so large counts are not printed in exponent notation.
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if item == nil {
+				delete(v, key)
+				continue
+			}
+			v[key] = normalize(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalize(item)
+		}
+		return v
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	}
+	return value
 }
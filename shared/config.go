@@ -1,16 +1,36 @@
 package shared
 
 import (
+	"time"
+
 	"github.com/pincher95/esctl/internal/client"
 )
 
 var (
-	Client                *client.Client
-	Context               string
-	ElasticsearchProtocol string
-	ElasticsearchUsername string
-	ElasticsearchPassword string
-	ElasticsearchHost     string
-	ElasticsearchPort     int
-	Debug                 bool
+	Client                     *client.Client
+	Context                    string
+	ElasticsearchProtocol      string
+	ElasticsearchUsername      string
+	ElasticsearchPassword      string
+	ElasticsearchAPIKey        string
+	ElasticsearchToken         string
+	ElasticsearchHost          string
+	ElasticsearchPort          int
+	ElasticsearchHosts         []string
+	ElasticsearchCloudID       string
+	ElasticsearchProxy         string
+	ElasticsearchHeaders       = map[string]string{}
+	ElasticsearchHostSelection string
+	ElasticsearchCACert        string
+	ElasticsearchClientCert    string
+	ElasticsearchClientKey     string
+	ElasticsearchServerName    string
+	ElasticsearchInsecure      bool
+	ElasticsearchTimeout       time.Duration
+	ElasticsearchRetries       int
+	ElasticsearchRetryBackoff  time.Duration
+	OutputFormat               string
+	Debug                      bool
+	ReadOnly                   bool
+	AllowWrites                bool
 )